@@ -0,0 +1,285 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/strutil"
+	"github.com/essentialkaos/ek/v13/terminal"
+
+	"github.com/essentialkaos/gomakegen/v3/generator"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// makefileInfo contains basic info extracted from makefile data
+type makefileInfo struct {
+	Options   string
+	Binaries  []string
+	TestPaths []string
+	Deps      []string
+	Targets   []string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	escSeqRegex   = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	targetRegex   = regexp.MustCompile(`^([a-zA-Z0-9_-]+):([^=]|$)`)
+	taskRegex     = regexp.MustCompile(`^  ([a-zA-Z0-9_-]+):$`)
+	subtaskRegex  = regexp.MustCompile(`^ +- task: (\S+)$`)
+	binListRegex  = regexp.MustCompile(`^all: (.*?)( ## .*)?$`)
+	depRegex      = regexp.MustCompile(`go get -d \S+ (\S+)$`)
+	testPathRegex = regexp.MustCompile(`-coverprofile=(?:\S*COVERAGE_FILE\S* |" \+ COVERAGE_FILE \+ " )([^{}"]+)`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkMakefile compares rendered data with existing file of given kind and
+// returns false if they are different. If makefile is not nil, summary of
+// changes in analyzed project is printed for outdated file.
+func checkMakefile(makefile *generator.Makefile, makefileData []byte, output, kind string) bool {
+	data, err := os.ReadFile(output)
+
+	if err != nil {
+		terminal.Error("Can't read %s: %v", output, err)
+		return false
+	}
+
+	curData := escSeqRegex.ReplaceAllString(string(data), "")
+	newData := escSeqRegex.ReplaceAllString(string(makefileData), "")
+
+	if curData == newData {
+		fmtc.Printfn("{g}%s {g*}%s{g} is up to date{!}", kind, output)
+		return true
+	}
+
+	fmtc.Printfn("{r}%s {r*}%s{r} is outdated{!}\n", kind, output)
+
+	var changes []string
+
+	if makefile != nil {
+		changes = getMakefileChanges(
+			parseMakefileInfo(curData, makefile.Format),
+			getMakefileInfo(makefile, newData),
+		)
+		changes = append(changes, getRequiresChanges(makefile)...)
+	}
+
+	if len(changes) == 0 {
+		changes = append(changes, "{s}~{!} "+kind+" content differs from generated one")
+	}
+
+	for _, change := range changes {
+		fmtc.Println("  " + change)
+	}
+
+	fmtc.NewLine()
+	fmtc.Printfn("{s-}Run gomakegen without --check option to update %s{!}", kind)
+
+	return false
+}
+
+// getMakefileInfo returns basic info about analyzed project
+func getMakefileInfo(makefile *generator.Makefile, data string) *makefileInfo {
+	info := &makefileInfo{
+		Options:  getOptionsFromHeader(data),
+		Binaries: makefile.Binaries,
+		Targets:  getTargets(data, makefile.Format),
+	}
+
+	if makefile.HasTests {
+		info.TestPaths = makefile.TestPaths
+	}
+
+	// Dependencies are listed in generated file only if there is no
+	// dependency manager
+	if !makefile.ModUsed && !makefile.GlideUsed && !makefile.DepUsed {
+		info.Deps = makefile.BaseImports
+	}
+
+	info.Targets = slices.DeleteFunc(info.Targets, func(target string) bool {
+		return slices.Contains(info.Binaries, target)
+	})
+
+	return info
+}
+
+// parseMakefileInfo extracts basic info from data of previously generated
+// file with given format
+func parseMakefileInfo(data, format string) *makefileInfo {
+	info := &makefileInfo{
+		Options: getOptionsFromHeader(data),
+		Targets: getTargets(data, format),
+	}
+
+	var isAllTask bool
+
+	for _, line := range strings.Split(data, "\n") {
+		if format == generator.FORMAT_TASKFILE {
+			switch {
+			case line == "  all:":
+				isAllTask = true
+			case taskRegex.MatchString(line):
+				isAllTask = false
+			case isAllTask && subtaskRegex.MatchString(line):
+				info.Binaries = append(info.Binaries, subtaskRegex.FindStringSubmatch(line)[1])
+			}
+		} else if match := binListRegex.FindStringSubmatch(line); match != nil {
+			info.Binaries = strutil.Fields(match[1])
+			continue
+		}
+
+		if match := testPathRegex.FindStringSubmatch(line); match != nil {
+			info.TestPaths = strutil.Fields(match[1])
+			continue
+		}
+
+		if match := depRegex.FindStringSubmatch(line); match != nil {
+			info.Deps = append(info.Deps, strings.Trim(match[1], "'"))
+		}
+	}
+
+	info.Targets = slices.DeleteFunc(info.Targets, func(target string) bool {
+		return slices.Contains(info.Binaries, target)
+	})
+
+	return info
+}
+
+// getOptionsFromHeader returns options from header of generated file
+func getOptionsFromHeader(data string) string {
+	for _, line := range strings.Split(data, "\n") {
+		if strings.HasPrefix(line, "# gomakegen ") {
+			return strings.TrimSpace(strutil.Exclude(line, "# gomakegen "))
+		}
+	}
+
+	return ""
+}
+
+// getTargets returns names of all targets (tasks or recipes) from data of
+// generated file with given format
+func getTargets(data, format string) []string {
+	var result []string
+	var isTasks bool
+
+	for _, line := range strings.Split(data, "\n") {
+		if format != generator.FORMAT_TASKFILE {
+			if match := targetRegex.FindStringSubmatch(line); match != nil {
+				result = append(result, match[1])
+			}
+
+			continue
+		}
+
+		// Tasks are defined in "tasks" section of Taskfile
+		switch {
+		case line == "tasks:":
+			isTasks = true
+		case line != "" && line[0] != ' ' && line[0] != '#':
+			isTasks = false
+		case isTasks && taskRegex.MatchString(line):
+			result = append(result, taskRegex.FindStringSubmatch(line)[1])
+		}
+	}
+
+	return result
+}
+
+// getMakefileChanges returns slice with descriptions of changes between
+// two makefiles
+func getMakefileChanges(cur, gen *makefileInfo) []string {
+	var result []string
+
+	if cur.Options != gen.Options {
+		result = append(result, fmt.Sprintf(
+			"{y}~{!} Options changed: {s}%s{!} → {*}%s{!}", cur.Options, gen.Options,
+		))
+	}
+
+	result = append(result, getSliceChanges("binary", cur.Binaries, gen.Binaries)...)
+	result = append(result, getSliceChanges("test path", cur.TestPaths, gen.TestPaths)...)
+	result = append(result, getSliceChanges("dependency", cur.Deps, gen.Deps)...)
+	result = append(result, getSliceChanges("target", cur.Targets, gen.Targets)...)
+
+	return result
+}
+
+// getRequiresChanges returns descriptions of differences between imports of
+// analyzed project and direct dependencies from go.mod
+func getRequiresChanges(makefile *generator.Makefile) []string {
+	if !makefile.ModUsed || len(makefile.Requires) == 0 {
+		return nil
+	}
+
+	var result []string
+	var usedModules []string
+
+	for _, pkg := range slices.Compact(slices.Sorted(slices.Values(
+		append(slices.Clone(makefile.BaseImports), makefile.TestImports...),
+	))) {
+		module := getPackageModule(pkg, makefile.Requires)
+
+		if module == "" {
+			result = append(result, fmt.Sprintf(
+				"{g}+{!} New dependency: {*}%s{!} {s-}(missing in go.mod){!}", pkg,
+			))
+		} else if !slices.Contains(usedModules, module) {
+			usedModules = append(usedModules, module)
+		}
+	}
+
+	for _, module := range makefile.Requires {
+		if !slices.Contains(usedModules, module) {
+			result = append(result, fmt.Sprintf(
+				"{r}-{!} Removed dependency: {*}%s{!} {s-}(unused in sources){!}", module,
+			))
+		}
+	}
+
+	return result
+}
+
+// getPackageModule returns path of module which contains given package
+func getPackageModule(pkg string, modules []string) string {
+	var result string
+
+	for _, module := range modules {
+		if (pkg == module || strings.HasPrefix(pkg, module+"/")) && len(module) > len(result) {
+			result = module
+		}
+	}
+
+	return result
+}
+
+// getSliceChanges returns descriptions of added and removed items
+func getSliceChanges(name string, cur, gen []string) []string {
+	var result []string
+
+	for _, item := range gen {
+		if !slices.Contains(cur, item) {
+			result = append(result, fmt.Sprintf("{g}+{!} New %s: {*}%s{!}", name, item))
+		}
+	}
+
+	for _, item := range cur {
+		if !slices.Contains(gen, item) {
+			result = append(result, fmt.Sprintf("{r}-{!} Removed %s: {*}%s{!}", name, item))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/essentialkaos/gomakegen/v3/generator"
+	"github.com/essentialkaos/gomakegen/v3/internal/testutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// testProject contains files of test project
+var testProject = map[string]string{
+	"go.mod": "module example.com/app\n\ngo 1.22\n\nrequire (\n" +
+		"\texample.org/used v1.0.0\n" +
+		"\texample.org/unused v1.0.0\n" +
+		"\texample.org/other v1.0.0 // indirect\n)\n",
+	"cmd/app/main.go": "package main\n\nimport (\n\t\"example.org/used/pkg\"\n\t\"example.org/missing\"\n)\n\n" +
+		"func main() { pkg.Run(); missing.Run() }\n",
+	"lib/lib.go":      "package lib\n\nimport \"fmt\"\n\nfunc Print() { fmt.Println() }\n",
+	"lib/lib_test.go": "package lib\n\nimport \"testing\"\n\nfunc TestPrint(t *testing.T) { Print() }\n",
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestParseMakefileInfo(t *testing.T) {
+	dir := testutil.CreateProject(t, testProject)
+
+	for _, format := range []string{generator.FORMAT_MAKE, generator.FORMAT_TASKFILE, generator.FORMAT_JUST} {
+		makefile, err := generator.Analyze(dir, generator.Options{Format: format})
+
+		if err != nil {
+			t.Fatalf("Can't analyze project: %v", err)
+		}
+
+		data, err := makefile.Render()
+
+		if err != nil {
+			t.Fatalf("Can't render %s: %v", format, err)
+		}
+
+		rendered := escSeqRegex.ReplaceAllString(string(data), "")
+		cur := parseMakefileInfo(rendered, format)
+
+		if strings.Join(cur.Binaries, " ") != "app" {
+			t.Errorf("Wrong binaries parsed from %s: %v", format, cur.Binaries)
+		}
+
+		if strings.Join(cur.TestPaths, " ") != "./lib" {
+			t.Errorf("Wrong test paths parsed from %s: %v", format, cur.TestPaths)
+		}
+
+		changes := getMakefileChanges(cur, getMakefileInfo(makefile, rendered))
+
+		if len(changes) != 0 {
+			t.Errorf("Unexpected changes for %s: %v", format, changes)
+		}
+	}
+}
+
+func TestRequiresChanges(t *testing.T) {
+	dir := testutil.CreateProject(t, testProject)
+	makefile, err := generator.Analyze(dir, generator.Options{})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	changes := strings.Join(getRequiresChanges(makefile), "\n")
+
+	if !strings.Contains(changes, "New dependency: {*}example.org/missing{!}") {
+		t.Errorf("Missing dependency is not reported:\n%s", changes)
+	}
+
+	if !strings.Contains(changes, "Removed dependency: {*}example.org/unused{!}") {
+		t.Errorf("Unused dependency is not reported:\n%s", changes)
+	}
+
+	if strings.Contains(changes, "example.org/used") || strings.Contains(changes, "example.org/other") {
+		t.Errorf("Used or indirect dependency is reported:\n%s", changes)
+	}
+}
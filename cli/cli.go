@@ -8,27 +8,16 @@ package cli
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
-	"bufio"
-	"bytes"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
-	"path/filepath"
 	"runtime"
 	"slices"
-	"sort"
-	"strconv"
-	"strings"
-
-	"go/parser"
-	"go/token"
 
 	"github.com/essentialkaos/ek/v13/fmtc"
 	"github.com/essentialkaos/ek/v13/fsutil"
-	"github.com/essentialkaos/ek/v13/mathutil"
 	"github.com/essentialkaos/ek/v13/options"
 	"github.com/essentialkaos/ek/v13/path"
-	"github.com/essentialkaos/ek/v13/strutil"
 	"github.com/essentialkaos/ek/v13/support"
 	"github.com/essentialkaos/ek/v13/support/apps"
 	"github.com/essentialkaos/ek/v13/support/deps"
@@ -40,7 +29,8 @@ import (
 	"github.com/essentialkaos/ek/v13/usage/completion/zsh"
 	"github.com/essentialkaos/ek/v13/usage/man"
 	"github.com/essentialkaos/ek/v13/usage/update"
-	"github.com/essentialkaos/ek/v13/version"
+
+	"github.com/essentialkaos/gomakegen/v3/generator"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -48,13 +38,16 @@ import (
 // App info
 const (
 	APP  = "GoMakeGen"
-	VER  = "3.3.3"
+	VER  = generator.VERSION
 	DESC = "Utility for generating makefiles for Go applications"
 )
 
 // Constants with options names
 const (
 	OPT_OUTPUT    = "o:output"
+	OPT_FORMAT    = "F:format"
+	OPT_TEMPLATES = "T:templates"
+	OPT_CI        = "I:ci"
 	OPT_GLIDE     = "g:glide"
 	OPT_DEP       = "d:dep"
 	OPT_MOD       = "m:mod"
@@ -62,6 +55,15 @@ const (
 	OPT_BENCHMARK = "B:benchmark"
 	OPT_RACE      = "R:race"
 	OPT_CGO       = "C:cgo"
+	OPT_CROSS     = "X:cross"
+	OPT_RELEASE   = "r:release"
+	OPT_LINT      = "L:lint"
+	OPT_GENERATE  = "G:generate"
+	OPT_DOCKER    = "K:docker"
+	OPT_CHECK     = "c:check"
+	OPT_DRY_RUN   = "n:dry-run"
+	OPT_DIFF      = "D:diff"
+	OPT_SKIP_INV  = "s:skip-invalid"
 	OPT_NO_COLOR  = "nc:no-color"
 	OPT_HELP      = "h:help"
 	OPT_VER       = "v:version"
@@ -71,43 +73,14 @@ const (
 	OPT_GENERATE_MAN = "generate-man"
 )
 
-// SEPARATOR_SIZE is default separator size
-const SEPARATOR_SIZE = 80
-
-// ////////////////////////////////////////////////////////////////////////////////// //
-
-// Makefile contains full info for makefile generation
-type Makefile struct {
-	BaseImports []string
-	TestImports []string
-	Binaries    []string
-
-	FuzzPaths []string
-	TestPaths []string
-
-	PkgBase string
-
-	MaxTargetNameSize int
-	MaxOptionNameSize int
-
-	HasTests         bool
-	Benchmark        bool
-	Race             bool
-	Strip            bool
-	CGO              bool
-	HasSubpackages   bool
-	HasStableImports bool
-
-	GlideUsed bool
-	DepUsed   bool
-	ModUsed   bool
-}
-
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Options map
 var optMap = options.Map{
-	OPT_OUTPUT:    {Value: "Makefile"},
+	OPT_OUTPUT:    {},
+	OPT_FORMAT:    {},
+	OPT_TEMPLATES: {},
+	OPT_CI:        {},
 	OPT_GLIDE:     {Type: options.BOOL},
 	OPT_DEP:       {Type: options.BOOL},
 	OPT_MOD:       {Type: options.BOOL},
@@ -115,6 +88,15 @@ var optMap = options.Map{
 	OPT_BENCHMARK: {Type: options.BOOL},
 	OPT_RACE:      {Type: options.BOOL},
 	OPT_CGO:       {Type: options.BOOL},
+	OPT_CROSS:     {Type: options.BOOL},
+	OPT_RELEASE:   {Type: options.BOOL},
+	OPT_LINT:      {Type: options.BOOL},
+	OPT_GENERATE:  {Type: options.BOOL},
+	OPT_DOCKER:    {Type: options.BOOL},
+	OPT_CHECK:     {Type: options.BOOL},
+	OPT_DRY_RUN:   {Type: options.BOOL},
+	OPT_DIFF:      {Type: options.BOOL},
+	OPT_SKIP_INV:  {Type: options.BOOL},
 	OPT_NO_COLOR:  {Type: options.BOOL},
 	OPT_HELP:      {Type: options.BOOL},
 	OPT_VER:       {Type: options.MIXED},
@@ -124,13 +106,6 @@ var optMap = options.Map{
 	OPT_GENERATE_MAN: {Type: options.BOOL},
 }
 
-// Paths for check package
-var checkPackageImports = []string{
-	"github.com/go-check/check",
-	"github.com/essentialkaos/check",
-	"gopkg.in/check.v1",
-}
-
 var colorTagApp, colorTagVer string
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -211,1089 +186,209 @@ func checkDir(dir string) {
 
 // process starts sources processing
 func process(dir string) {
-	sources := fsutil.ListAllFiles(
-		dir, true,
-		fsutil.ListingFilter{
-			MatchPatterns: []string{"*.go"},
-			SizeGreater:   1, // Ignore empty files
-		},
-	)
-
-	sources = filterSources(sources)
-	makefile := generateMakefile(sources, dir)
-
-	exportMakefile(makefile)
-}
-
-// filterSources removes sources from vendor directory from sources list
-func filterSources(sources []string) []string {
-	var result []string
-
-	for _, source := range sources {
-		if strings.HasPrefix(source, "vendor/") {
-			continue
-		}
-
-		result = append(result, source)
-	}
-
-	return result
-}
-
-// exportMakefile renders makefile and write data to file
-func exportMakefile(makefile *Makefile) {
-	switch {
-	case makefile.DepUsed:
-		fmtc.Println("{r}▲ Warning! Dep is deprecated and should not be used for new projects.{!}\n")
-	case makefile.GlideUsed:
-		fmtc.Println("{r}▲ Warning! Glide is deprecated and should not be used for new projects.{!}\n")
-	}
-
-	err := os.WriteFile(options.GetS(OPT_OUTPUT), makefile.Render(), 0644)
+	makefile, err := generator.Analyze(dir, getGeneratorOptions())
 
 	if err != nil {
-		terminal.Error(err)
+		printAnalysisError(err)
 		os.Exit(1)
 	}
 
-	fmtc.Printfn("{g}Makefile successfully created as {g*}%s{!}", options.GetS(OPT_OUTPUT))
-}
-
-// generateMakefile collects imports, process options and generate makefile struct
-func generateMakefile(sources []string, dir string) *Makefile {
-	makefile := collectImports(sources, dir)
-	goVersion := getGoVersion()
+	printSkippedSources(makefile)
 
-	applyOptionsFromMakefile(dir+"/"+options.GetS(OPT_OUTPUT), makefile)
+	isActual := true
 
-	makefile.Benchmark = makefile.Benchmark || options.GetB(OPT_BENCHMARK)
-	makefile.Race = makefile.Race || options.GetB(OPT_RACE)
-	makefile.CGO = makefile.CGO || options.GetB(OPT_CGO)
-	makefile.Strip = makefile.Strip || options.GetB(OPT_STRIP)
-	makefile.GlideUsed = makefile.GlideUsed || options.GetB(OPT_GLIDE) || fsutil.IsExist(dir+"/glide.yaml")
-	makefile.DepUsed = makefile.DepUsed || options.GetB(OPT_DEP) || fsutil.IsExist(dir+"/Gopkg.toml")
-	makefile.ModUsed = makefile.ModUsed || options.GetB(OPT_MOD) || fsutil.IsExist(dir+"/go.mod")
-
-	if !goVersion.IsZero() && (goVersion.Major() > 1 || goVersion.Minor() > 17) {
-		makefile.ModUsed = true
-	}
-
-	makefile.HasStableImports = containsStableImports(makefile.BaseImports)
-	makefile.HasStableImports = makefile.HasStableImports || containsStableImports(makefile.TestImports)
-
-	makefile.Cleanup(dir)
-
-	return makefile
-}
-
-// collectImports collects import from source files and returns imports for
-// base sources, test sources and slice with binaries
-func collectImports(sources []string, dir string) *Makefile {
-	baseSources, testSources := splitSources(sources)
-
-	baseImports, binaries, hasSubPkgs := extractBaseImports(baseSources, dir)
-	testImports, testPaths := extractTestImports(testSources, dir)
-	fuzzPaths := collectFuzzPaths(baseSources, dir)
-
-	return &Makefile{
-		BaseImports:    baseImports,
-		TestImports:    testImports,
-		FuzzPaths:      fuzzPaths,
-		TestPaths:      testPaths,
-		PkgBase:        getBasePkgPath(dir),
-		Binaries:       binaries,
-		HasTests:       hasTests(sources),
-		HasSubpackages: hasSubPkgs,
-	}
-}
-
-// splitSources splits sources to two slices - with base sources and test sources
-func splitSources(sources []string) ([]string, []string) {
-	if !hasTests(sources) {
-		return sources, nil
-	}
+	for _, module := range makefile.Modules {
+		output := path.Join(dir, module.Dir, module.Makefile.Output)
+		isActual = outputMakefile(module.Makefile, output) && isActual
 
-	var bSources, tSources []string
-
-	for _, source := range sources {
-		if isTestSource(source) {
-			tSources = append(tSources, source)
-		} else {
-			bSources = append(bSources, source)
-		}
-	}
-
-	return bSources, tSources
-}
-
-// extractBaseImports extracts base imports from given source files
-func extractBaseImports(sources []string, dir string) ([]string, []string, bool) {
-	importsMap := make(map[string]bool)
-	binaries := make([]string, 0)
-	hasSubPkgs := false
-
-	for _, source := range sources {
-		imports, isBinary := extractImports(source, dir)
-
-		for _, path := range imports {
-			importsMap[path] = true
-		}
-
-		// Append to slice only binaries in root directory
-		if isBinary && !strings.Contains(source, "/") {
-			binaries = append(binaries, source)
-		}
-
-		if !hasSubPkgs && strings.Contains(source, "/") {
-			hasSubPkgs = true
-		}
-	}
-
-	return importMapToSlice(importsMap), binaries, hasSubPkgs
-}
-
-// extractTestImports extracts test imports from given source files
-func extractTestImports(sources []string, dir string) ([]string, []string) {
-	if len(sources) == 0 {
-		return nil, nil
-	}
-
-	importsMap := make(map[string]bool)
-	testPaths := make(map[string]bool)
-
-	for _, source := range sources {
-		imports, _ := extractImports(source, dir)
-		basePath := path.Dir(source)
-
-		for _, path := range imports {
-			importsMap[path] = true
-		}
-
-		testPaths["./"+basePath] = true
-	}
-
-	return importMapToSlice(importsMap), importMapToSlice(testPaths)
-}
-
-// collectFuzzPaths collects paths with fuzz tests
-func collectFuzzPaths(sources []string, dir string) []string {
-	var result []string
-
-	for _, source := range sources {
-		if hasFuzzTests(source, dir) {
-			result = append(result, path.Dir(source))
+		if module.Makefile.Docker {
+			output = path.Join(dir, module.Dir, generator.DOCKERFILE)
+			isActual = outputDockerfile(module.Makefile, output) && isActual
 		}
 	}
 
-	return result
-}
-
-// cleanupImports removes internal packages and local imports
-func cleanupImports(imports []string, dir string) []string {
-	if len(imports) == 0 {
-		return nil
-	}
-
-	result := make(map[string]bool)
-	gopath := os.Getenv("GOPATH")
-	basePath := getBasePkgPath(dir)
-
-	for _, imp := range imports {
-		if !isExternalPackage(imp) {
-			continue
-		}
-
-		if strings.HasPrefix(imp, basePath) {
-			continue
-		}
-
-		result[getPackageRoot(imp, gopath)] = true
-	}
-
-	return importMapToSlice(result)
-}
-
-// cleanupBinaries removes .go suffix from names of binaries
-func cleanupBinaries(binaries []string) []string {
-	var result []string
-
-	for _, bin := range binaries {
-		result = append(result, strings.TrimSuffix(bin, ".go"))
-	}
-
-	return result
-}
-
-// extractImports returns slice with all imports in source file
-func extractImports(source, dir string) ([]string, bool) {
-	fset := token.NewFileSet()
-	file := path.Join(dir, source)
-	f, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly)
+	isActual = outputMakefile(makefile, path.Join(dir, makefile.Output)) && isActual
 
-	if err != nil {
-		terminal.Error(err)
-		os.Exit(1)
+	if makefile.Docker {
+		isActual = outputDockerfile(makefile, path.Join(dir, generator.DOCKERFILE)) && isActual
 	}
 
-	var result []string
-	var isBinary bool
-
-	for _, imp := range f.Imports {
-		if f.Name.String() == "main" {
-			isBinary = true
-		}
-
-		result = append(result, strings.Trim(imp.Path.Value, "\""))
+	if makefile.CI != "" {
+		isActual = outputCI(makefile, path.Join(dir, makefile.CIOutput)) && isActual
 	}
 
-	return result, isBinary
-}
-
-// hasFuzzTest returns true if given source package
-func hasFuzzTests(source, dir string) bool {
-	fset := token.NewFileSet()
-	file := path.Join(dir, source)
-	f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
-
-	if err != nil {
-		terminal.Error(err)
+	if !isActual {
 		os.Exit(1)
 	}
-
-	if len(f.Comments) == 0 {
-		return false
-	}
-
-	return strings.Contains(f.Comments[0].Text(), "+build gofuzz")
-}
-
-// hasTests returns true if project has tests
-func hasTests(sources []string) bool {
-	for _, source := range sources {
-		if isTestSource(source) {
-			return true
-		}
-	}
-
-	return false
 }
 
-// isTestSource returns true if given file is tests
-func isTestSource(source string) bool {
-	return strings.HasSuffix(source, "_test.go")
-}
+// getGeneratorOptions returns generation options based on command-line options
+func getGeneratorOptions() generator.Options {
+	return generator.Options{
+		Output:    options.GetS(OPT_OUTPUT),
+		Format:    options.GetS(OPT_FORMAT),
+		Templates: options.GetS(OPT_TEMPLATES),
+		CI:        options.GetS(OPT_CI),
+		Glide:     options.GetB(OPT_GLIDE),
+		Dep:       options.GetB(OPT_DEP),
+		Mod:       options.GetB(OPT_MOD),
+		Strip:     options.GetB(OPT_STRIP),
+		Benchmark: options.GetB(OPT_BENCHMARK),
+		Race:      options.GetB(OPT_RACE),
+		CGO:       options.GetB(OPT_CGO),
+		Cross:     options.GetB(OPT_CROSS),
+		Release:   options.GetB(OPT_RELEASE),
+		Lint:      options.GetB(OPT_LINT),
+		Generate:  options.GetB(OPT_GENERATE),
+		Docker:    options.GetB(OPT_DOCKER),
 
-// getPackageRoot returns root for package
-func getPackageRoot(pkg, gopath string) string {
-	if isPackageRoot(gopath + "/src/" + pkg) {
-		return pkg
+		SkipInvalid: options.GetB(OPT_SKIP_INV),
 	}
-
-	pkgSlice := strings.Split(pkg, "/")
-
-	for i := 2; i < len(pkgSlice); i++ {
-		path := strings.Join(pkgSlice[:i], "/")
-
-		if isPackageRoot(gopath + "/src/" + path) {
-			return path
-		}
-	}
-
-	return pkg
-}
-
-// isPackageRoot returns true if given path is root for package
-func isPackageRoot(path string) bool {
-	if !fsutil.IsExist(path + "/.git") {
-		return false
-	}
-
-	files := fsutil.List(path, true, fsutil.ListingFilter{MatchPatterns: []string{"*.go"}})
-
-	return len(files) != 0
 }
 
-// isExternalPackage returns true if given package is external
-func isExternalPackage(pkg string) bool {
-	pkgSlice := strings.Split(pkg, "/")
-
-	if len(pkgSlice) == 0 || !strings.Contains(pkgSlice[0], ".") {
-		return false
-	}
-	return true
-}
+// printAnalysisError prints error returned by analyzer
+func printAnalysisError(err error) {
+	var parseErrs generator.ParseErrors
 
-// importMapToSlice converts map with package names to string slice
-func importMapToSlice(imports map[string]bool) []string {
-	if len(imports) == 0 {
-		return nil
-	}
-
-	var result []string
-
-	for path := range imports {
-		result = append(result, path)
+	if !errors.As(err, &parseErrs) {
+		terminal.Error(err)
+		return
 	}
 
-	sort.Strings(result)
-
-	return result
-}
+	terminal.Error("Can't parse %d source file(s):\n", len(parseErrs.Files()))
 
-// containsPackage returns true if imports contains given packages
-func containsPackage(imports []string, pkgs []string) bool {
-	for _, pkg := range pkgs {
-		if slices.Contains(imports, pkg) {
-			return true
-		}
+	for _, parseErr := range parseErrs {
+		terminal.Error("  %v", parseErr)
 	}
 
-	return false
+	terminal.Error(
+		"\nFix these errors or use --%s option to skip invalid sources",
+		getOptionName(OPT_SKIP_INV),
+	)
 }
 
-// getBasePkgPath returns base package path
-func getBasePkgPath(dir string) string {
-	gopath, _ := filepath.EvalSymlinks(os.Getenv("GOPATH"))
-	absDir, _ := filepath.Abs(dir)
-	absDir, _ = filepath.EvalSymlinks(absDir)
+// printSkippedSources prints warning about sources skipped due to
+// parsing errors
+func printSkippedSources(makefile *generator.Makefile) {
+	parseErrs := slices.Clone(makefile.ParseErrors)
 
-	return strutil.Exclude(absDir, gopath+"/src/")
-}
-
-// containsStableImports returns true if imports contains stable import services path
-func containsStableImports(imports []string) bool {
-	if len(imports) == 0 {
-		return false
+	for _, module := range makefile.Modules {
+		parseErrs = append(parseErrs, module.Makefile.ParseErrors...)
 	}
 
-	for _, pkg := range imports {
-		if strings.HasPrefix(pkg, "gopkg.in") {
-			return true
-		}
-	}
-
-	return false
-}
-
-// applyOptionsFromFile reads used options from previously generated Makefile
-// and applies it to makefile struct
-func applyOptionsFromMakefile(file string, m *Makefile) {
-	if !fsutil.IsExist(file) {
+	if len(parseErrs) == 0 {
 		return
 	}
 
-	opts := extractOptionsFromMakefile(file)
+	terminal.Warn("▲ Next sources were skipped due to parsing errors:\n")
 
-	if opts == "" {
-		return
+	for _, parseErr := range parseErrs {
+		terminal.Warn("  %v", parseErr)
 	}
 
-	for _, opt := range strutil.Fields(opts) {
-		switch strings.TrimLeft(opt, "-") {
-		case getOptionName(OPT_GLIDE):
-			m.GlideUsed = true
-		case getOptionName(OPT_DEP):
-			m.DepUsed = true
-		case getOptionName(OPT_STRIP):
-			m.Strip = true
-		case getOptionName(OPT_BENCHMARK):
-			m.Benchmark = true
-		case getOptionName(OPT_RACE):
-			m.Race = true
-		case getOptionName(OPT_CGO):
-			m.CGO = true
-		}
-	}
+	fmtc.NewLine()
 }
 
-// extractOptionsFromMakefile extracts options from previously generated Makefile
-func extractOptionsFromMakefile(file string) string {
-	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+// outputMakefile saves, prints or checks makefile depending on options. It
+// returns false if existing makefile is outdated.
+func outputMakefile(makefile *generator.Makefile, output string) bool {
+	data, err := makefile.Render()
 
 	if err != nil {
-		return ""
-	}
-
-	defer fd.Close()
-
-	r := bufio.NewReader(fd)
-	s := bufio.NewScanner(r)
-
-	for s.Scan() {
-		text := s.Text()
-
-		if !strings.HasPrefix(text, "# gomakegen ") {
-			continue
-		}
-
-		return strutil.Exclude(text, "# gomakegen ")
-	}
-
-	return ""
-}
-
-// ////////////////////////////////////////////////////////////////////////////////// //
-
-// Cleanup cleans imports and binaries
-func (m *Makefile) Cleanup(dir string) {
-	m.BaseImports = cleanupImports(m.BaseImports, dir)
-	m.TestImports = cleanupImports(m.TestImports, dir)
-
-	m.Binaries = cleanupBinaries(m.Binaries)
-
-	sort.Strings(m.Binaries)
-}
-
-// Render returns makefile data
-func (m *Makefile) Render() []byte {
-	var result string
-
-	result += m.getHeader()
-	result += m.getTargets()
-
-	return []byte(result)
-}
-
-// ////////////////////////////////////////////////////////////////////////////////// //
-
-// getHeader returns header data
-func (m *Makefile) getHeader() string {
-	var result string
-
-	result += getSeparator() + "\n\n"
-	result += m.getGenerationComment()
-	result += getSeparator() + "\n\n"
-	result += m.getDefaultVariables()
-	result += getSeparator() + "\n\n"
-	result += m.getDefaultGoal() + "\n"
-	result += m.getPhony() + "\n"
-	result += getSeparator() + "\n\n"
-
-	return result
-}
-
-// getTargets returns targets data
-func (m *Makefile) getTargets() string {
-	var result string
-
-	result += m.getBinTarget()
-	result += m.getInstallTarget()
-	result += m.getUninstallTarget()
-	result += m.getInitTarget()
-	result += m.getDepsTarget()
-	result += m.getTestDepsTarget()
-	result += m.getUpdateTarget()
-	result += m.getVendorTarget()
-	result += m.getTestTarget()
-	result += m.getFuzzTarget()
-	result += m.getBenchTarget()
-	result += m.getGlideTarget()
-	result += m.getDepTarget()
-	result += m.getModTarget()
-	result += m.getFmtTarget()
-	result += m.getVetTarget()
-	result += m.getCleanTarget()
-	result += m.getHelpTarget()
-
-	result += getSeparator() + "\n"
-
-	return result
-}
-
-// codebeat:disable[ABC]
-
-// getPhony returns PHONY part of makefile
-func (m *Makefile) getPhony() string {
-	phony := []string{"fmt", "vet"}
-
-	if len(m.Binaries) != 0 {
-		phony = append(phony, "all", "install", "uninstall", "clean")
-	}
-
-	if len(m.BaseImports) != 0 || m.ModUsed {
-		phony = append(phony, "deps", "update")
-	}
-
-	if len(m.TestImports) != 0 {
-		phony = append(phony, "test")
-	}
-
-	if !m.GlideUsed && !m.DepUsed && !m.ModUsed {
-		if len(m.TestImports) != 0 {
-			phony = append(phony, "deps-test")
-		}
-	} else {
-		phony = append(phony, "init", "vendor")
-	}
-
-	if len(m.FuzzPaths) != 0 {
-		phony = append(phony, "gen-fuzz")
-	}
-
-	if m.Benchmark {
-		phony = append(phony, "benchmark")
-	}
-
-	for _, target := range phony {
-		m.MaxTargetNameSize = mathutil.Max(m.MaxTargetNameSize, len(target))
-	}
-
-	if m.GlideUsed {
-		phony = append(phony, "glide-create", "glide-install", "glide-update")
-	}
-
-	if m.DepUsed {
-		phony = append(phony, "dep-init", "dep-update", "dep-vendor")
-	}
-
-	if m.ModUsed {
-		phony = append(phony, "tidy", "mod-init", "mod-update", "mod-download", "mod-vendor")
-	}
-
-	phony = append(phony, "help")
-
-	return ".PHONY = " + strings.Join(phony, " ") + "\n"
-}
-
-// codebeat:enable[ABC]
-
-// getDefaultGoal returns DEFAULT_GOAL part of makefile
-func (m *Makefile) getDefaultGoal() string {
-	return ".DEFAULT_GOAL := help"
-}
-
-// getBinTarget generates target for "all" command and all sub-targets
-// for each binary
-func (m *Makefile) getBinTarget() string {
-	if len(m.Binaries) == 0 {
-		return ""
-	}
-
-	result := "all: " + strings.Join(m.Binaries, " ") + " ## Build all binaries\n\n"
-
-	for i, bin := range m.Binaries {
-		result += bin + ":\n"
-		result += getActionText(i+1, len(m.Binaries), "Building "+bin+"…")
-
-		ldFlags := m.getLDFlags()
-
-		if ldFlags != "" {
-			result += "\t@go build $(VERBOSE_FLAG) -ldflags=\"" + ldFlags + "\" " + bin + ".go\n"
-		} else {
-			result += "\t@go build $(VERBOSE_FLAG) " + bin + ".go\n"
-		}
-
-		result += "\n"
-	}
-
-	return result
-}
-
-// getInstallTarget generates target for "install" command
-func (m *Makefile) getInstallTarget() string {
-	if len(m.Binaries) == 0 {
-		return ""
-	}
-
-	result := "install: ## Install all binaries\n"
-	result += getActionText(1, 1, "Installing binaries…")
-
-	for _, bin := range m.Binaries {
-		result += "\t@cp " + bin + " /usr/bin/" + bin + "\n"
-	}
-
-	return result + "\n"
-}
-
-// getUninstallTarget generates target for "uninstall" command
-func (m *Makefile) getUninstallTarget() string {
-	if len(m.Binaries) == 0 {
-		return ""
-	}
-
-	result := "uninstall: ## Uninstall all binaries\n"
-	result += getActionText(1, 1, "Removing installed binaries…")
-
-	for _, bin := range m.Binaries {
-		result += "\t@rm -f /usr/bin/" + bin + "\n"
-	}
-
-	return result + "\n"
-}
-
-// getInitTarget generates target for "init" command
-func (m *Makefile) getInitTarget() string {
-	switch {
-	case m.GlideUsed:
-		return "init: glide-update ## Initialize new workspace\n\n"
-	case m.DepUsed:
-		return "init: dep-vendor ## Initialize new workspace\n\n"
-	case m.ModUsed:
-		return "init: mod-init ## Initialize new module\n\n"
-	}
-
-	return ""
-}
-
-// getDepsTarget generates target for "deps" command
-func (m *Makefile) getDepsTarget() string {
-	if len(m.BaseImports) == 0 {
-		if m.ModUsed {
-			return "deps: mod-download ## Download dependencies\n\n"
-		}
-
-		return ""
-	}
-
-	result := "deps: "
-
-	switch {
-	case m.GlideUsed:
-		result += "glide-install "
-	case m.DepUsed:
-		result += "dep-update "
-	case m.ModUsed:
-		result += "mod-download "
-	}
-
-	result += "## Download dependencies\n"
-
-	if m.GlideUsed || m.DepUsed || m.ModUsed {
-		return result + "\n"
-	}
-
-	for _, pkg := range m.BaseImports {
-		result += "\t@go get -d $(VERBOSE_FLAG) " + pkg + "\n"
+		terminal.Error(err)
+		os.Exit(1)
 	}
 
-	return result + "\n"
-}
-
-// getVendorTarget generates target for "vendor" command
-func (m *Makefile) getVendorTarget() string {
 	switch {
-	case m.GlideUsed:
-		return "vendor: glide-create ## Make vendored copy of dependencies\n\n"
-	case m.DepUsed:
-		return "vendor: dep-init ## Make vendored copy of dependencies\n\n"
-	case m.ModUsed:
-		return "vendor: mod-vendor ## Make vendored copy of dependencies\n\n"
+	case options.GetB(OPT_CHECK):
+		return checkMakefile(makefile, data, output, "Makefile")
+	case options.GetB(OPT_DRY_RUN):
+		os.Stdout.Write(data)
+	case options.GetB(OPT_DIFF):
+		printMakefileDiff(data, output, "Makefile")
+	default:
+		exportMakefile(makefile, data, output)
 	}
 
-	return ""
+	return true
 }
 
-// getUpdateTarget generates target for "update" command
-func (m *Makefile) getUpdateTarget() string {
+// exportMakefile writes rendered makefile data to file
+func exportMakefile(makefile *generator.Makefile, data []byte, output string) {
 	switch {
-	case m.GlideUsed:
-		return "update: glide-update ## Update dependencies to the latest versions\n\n"
-	case m.DepUsed:
-		return "update: dep-update ## Update dependencies to the latest versions\n\n"
-	case m.ModUsed:
-		return "update: mod-update ## Update dependencies to the latest versions\n\n"
-	}
-
-	result := "update: ## Update dependencies to the latest versions\n"
-	result += getActionText(1, 1, "Updating dependencies…")
-	result += "\t@go get -d -u $(VERBOSE_FLAG) ./...\n\n"
-
-	return result
-}
-
-// getDepsTarget generates target for "deps-test" command
-func (m *Makefile) getTestDepsTarget() string {
-	if len(m.TestImports) == 0 {
-		return ""
-	}
-
-	pkgMngUsed := m.DepUsed || m.GlideUsed || m.ModUsed
-
-	if pkgMngUsed {
-		return ""
-	}
-
-	result := "deps-test: ## Download dependencies for tests\n"
-	result += getActionText(1, 1, "Downloading tests dependencies…")
-
-	if !pkgMngUsed {
-		for _, pkg := range m.TestImports {
-			result += "\t@go get -d $(VERBOSE_FLAG) " + pkg + "\n"
-		}
-	}
-
-	return result + "\n"
-}
-
-// getTestTarget generates target for "test" command
-func (m *Makefile) getTestTarget() string {
-	if !m.HasTests {
-		return ""
-	}
-
-	targets := "."
-
-	if m.HasSubpackages {
-		if len(m.TestPaths) > 3 {
-			targets = "./..."
-		} else {
-			targets = strings.Join(m.TestPaths, " ")
-		}
-	}
-
-	testTarget := "@go test $(VERBOSE_FLAG)"
-
-	if m.Race {
-		testTarget += " -race -covermode=atomic"
-	} else {
-		testTarget += " -covermode=count"
-	}
-
-	result := "test: ## Run tests\n"
-	result += getActionText(1, 1, "Starting tests…")
-	result += "ifdef COVERAGE_FILE ## Save coverage data into file (String)\n"
-	result += "\t" + testTarget + " -coverprofile=$(COVERAGE_FILE) " + strings.Join(m.TestPaths, " ") + "\n"
-	result += "else\n"
-	result += "\t" + testTarget + " " + targets + "\n"
-	result += "endif\n\n"
-
-	m.MaxOptionNameSize = mathutil.Max(m.MaxOptionNameSize, len("COVERAGE_FILE"))
-
-	return result
-}
-
-// getFuzzTarget generates target for "fuzz" command
-func (m *Makefile) getFuzzTarget() string {
-	if len(m.FuzzPaths) == 0 {
-		return ""
-	}
-
-	result := "gen-fuzz: ## Generate archives for fuzz testing\n"
-	result += "\t@which go-fuzz-build &>/dev/null || go install github.com/dvyukov/go-fuzz/go-fuzz-build@latest\n"
-	result += getActionText(1, 1, "Generating fuzzing data…")
-
-	for _, pkg := range m.FuzzPaths {
-		if pkg == "." {
-			result += fmt.Sprintf("\t@go-fuzz-build -o fuzz.zip %s\n", m.PkgBase)
-		} else {
-			pkgName := strings.ReplaceAll(pkg, "/", "-")
-			binName := pkgName + "-fuzz.zip"
-
-			result += fmt.Sprintf("\t@go-fuzz-build -o %s %s/%s\n", binName, m.PkgBase, pkg)
-		}
-	}
-
-	return result + "\n"
-}
-
-// getBenchTarget generates target for "benchmark" command
-func (m *Makefile) getBenchTarget() string {
-	if !m.Benchmark {
-		return ""
-	}
-
-	result := "benchmark: ## Run benchmarks\n"
-	result += getActionText(1, 1, "Starting benchmarks…")
-
-	if containsPackage(m.TestImports, checkPackageImports) {
-		result += "\t@go test -check.v -check.b -check.bmem\n"
-	} else {
-		result += "\t@go test -bench=.\n"
-	}
-
-	return result + "\n"
-}
-
-// getFmtTarget generates target for "fmt" command
-func (m *Makefile) getFmtTarget() string {
-	result := "fmt: ## Format source code with gofmt\n"
-	result += getActionText(1, 1, "Formatting sources…")
-	result += "\t@find . -name \"*.go\" -exec gofmt -s -w {} \\;\n"
-
-	return result + "\n"
-}
-
-// getVetTarget generates target for "vet" command
-func (m *Makefile) getVetTarget() string {
-	result := "vet: ## Runs 'go vet' over sources\n"
-	result += getActionText(1, 1, "Running 'go vet' over sources…")
-	result += "\t@go vet -composites=false -printfuncs=LPrintf,TLPrintf,TPrintf,log.Debug,log.Info,log.Warn,log.Error,log.Critical,log.Print ./...\n"
-
-	return result + "\n"
-}
-
-// getCleanTarget generates target for "clean" command
-func (m *Makefile) getCleanTarget() string {
-	if len(m.Binaries) == 0 {
-		return ""
-	}
-
-	result := "clean: ## Remove generated files\n"
-	result += getActionText(1, 1, "Removing built binaries…")
-
-	for _, bin := range m.Binaries {
-		result += "\t@rm -f " + bin + "\n"
+	case makefile.DepUsed:
+		fmtc.Println("{r}▲ Warning! Dep is deprecated and should not be used for new projects.{!}\n")
+	case makefile.GlideUsed:
+		fmtc.Println("{r}▲ Warning! Glide is deprecated and should not be used for new projects.{!}\n")
 	}
 
-	return result + "\n"
-}
+	err := os.WriteFile(output, data, 0644)
 
-// getGlideTarget generates target for "glide-install" and
-// "glide-update" commands
-func (m *Makefile) getGlideTarget() string {
-	if !m.GlideUsed {
-		return ""
+	if err != nil {
+		terminal.Error(err)
+		os.Exit(1)
 	}
 
-	result := "glide-create:\n"
-	result += getActionText(1, 1, "Glide initialization…")
-	result += "\t@which glide &>/dev/null || (printf '\\e[31mGlide is not installed\\e[0m' ; exit 1)\n"
-	result += "\t@glide init\n"
-	result += "\n"
-
-	result += "glide-install:\n"
-	result += getActionText(1, 1, "Installing dependencies…")
-	result += "\t@which glide &>/dev/null || (printf '\\e[31mGlide is not installed\\e[0m' ; exit 1)\n"
-	result += "\t@test -s glide.yaml || glide init\n"
-	result += "\t@glide install\n"
-	result += "\n"
-
-	result += "glide-update:\n"
-	result += getActionText(1, 1, "Updating dependencies…")
-	result += "\t@which glide &>/dev/null || (printf '\\e[31mGlide is not installed\\e[0m' ; exit 1)\n"
-	result += "\t@test -s glide.yaml || glide init\n"
-	result += "\t@glide update\n\n"
-
-	return result
+	fmtc.Printfn("{g}Makefile successfully created as {g*}%s{!}", output)
 }
 
-// getDepTarget generate target for "dep-init" and "dep-update" commands
-func (m *Makefile) getDepTarget() string {
-	if !m.DepUsed {
-		return ""
-	}
+// outputCI saves, prints or checks CI configuration depending on options. It
+// returns false if existing configuration is outdated.
+func outputCI(makefile *generator.Makefile, output string) bool {
+	data, err := makefile.RenderCI()
 
-	result := "dep-init:\n"
-	result += getActionText(1, 1, "Dep initialization…")
-	result += "\t@which dep &>/dev/null || go get -u -v github.com/golang/dep/cmd/dep\n"
-	result += "\t@dep init\n\n"
-
-	result += "dep-update:\n"
-	result += getActionText(1, 1, "Updating dependencies…")
-	result += "\t@which dep &>/dev/null || go get -u -v github.com/golang/dep/cmd/dep\n"
-	result += "\t@test -s Gopkg.toml || dep init\n"
-	result += "\t@test -s Gopkg.lock && dep ensure -update || dep ensure\n\n"
-
-	result += "dep-vendor:\n"
-	result += getActionText(1, 1, "Vendoring dependencies…")
-	result += "\t@which dep &>/dev/null || go get -u -v github.com/golang/dep/cmd/dep\n"
-	result += "\t@dep ensure\n\n"
-
-	return result
-}
-
-// getModTarget generates target for "mod-init" and "mod-update" commands
-func (m *Makefile) getModTarget() string {
-	if !m.ModUsed {
-		return ""
+	if err != nil {
+		terminal.Error(err)
+		os.Exit(1)
 	}
 
-	result := "tidy: ## Cleanup dependencies\n"
-	result += getActionText(1, 2, "Tidying up dependencies…")
-	result += "ifdef COMPAT ## Compatible Go version (String)\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG) -compat=$(COMPAT) -go=$(COMPAT)\n"
-	result += "else\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG)\n"
-	result += "endif\n"
-	result += getActionText(2, 2, "Updating vendored dependencies…")
-	result += "\t@test -d vendor && rm -rf vendor && go mod vendor $(VERBOSE_FLAG) || :\n\n"
-
-	result += "mod-init:\n"
-	result += getActionText(1, 3, "Modules initialization…")
-	result += "\t@rm -f go.mod go.sum\n"
-	result += "ifdef MODULE_PATH ## Module path for initialization (String)\n"
-	result += "\t@go mod init $(MODULE_PATH)\n"
-	result += "else\n"
-	result += "\t@go mod init\n"
-	result += "endif\n\n"
-	result += getActionText(2, 3, "Dependencies cleanup…")
-	result += "ifdef COMPAT ## Compatible Go version (String)\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG) -compat=$(COMPAT) -go=$(COMPAT)\n"
-	result += "else\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG)\n"
-	result += "endif\n"
-	result += getActionText(3, 3, "Stripping toolchain info…")
-	result += "\t@grep -q 'toolchain ' go.mod && go mod edit -toolchain=none || :\n\n"
-
-	result += "mod-update:\n"
-	result += getActionText(1, 4, "Updating dependencies…")
-	result += "ifdef UPDATE_ALL ## Update all dependencies (Flag)\n"
-	result += "\t@go get -u $(VERBOSE_FLAG) all\n"
-	result += "else\n"
-	result += "\t@go get -u $(VERBOSE_FLAG) ./...\n"
-	result += "endif\n\n"
-	result += getActionText(2, 4, "Stripping toolchain info…")
-	result += "\t@grep -q 'toolchain ' go.mod && go mod edit -toolchain=none || :\n\n"
-	result += getActionText(3, 4, "Dependencies cleanup…")
-	result += "ifdef COMPAT\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG) -compat=$(COMPAT)\n"
-	result += "else\n"
-	result += "\t@go mod tidy $(VERBOSE_FLAG)\n"
-	result += "endif\n\n"
-	result += getActionText(4, 4, "Updating vendored dependencies…")
-	result += "\t@test -d vendor && rm -rf vendor && go mod vendor $(VERBOSE_FLAG) || :\n\n"
-
-	result += "mod-download:\n"
-	result += getActionText(1, 1, "Downloading dependencies…")
-	result += "\t@go mod download\n\n"
-
-	result += "mod-vendor:\n"
-	result += getActionText(1, 1, "Vendoring dependencies…")
-	result += "\t@rm -rf vendor && go mod vendor $(VERBOSE_FLAG) || :\n\n"
-
-	m.MaxOptionNameSize = mathutil.Max(m.MaxOptionNameSize, len("MODULE_PATH"))
-
-	return result
+	return outputFile(data, output, "CI configuration")
 }
 
-// getHelpTarget generates target for "help" command
-func (m *Makefile) getHelpTarget() string {
-	targetNameSize := strconv.Itoa(m.MaxTargetNameSize)
-	optionNameSize := strconv.Itoa(m.MaxOptionNameSize)
-
-	result := "help: ## Show this info\n"
-	result += "\t@printf '\\n\\033[1mTargets:\\033[0m\\n\\n'\n"
-	result += "\t@grep -E '^[a-zA-Z_-]+:.*?## .*$$' $(MAKEFILE_LIST) \\\n"
-	result += "\t\t| awk 'BEGIN {FS = \":.*?## \"}; {printf \"  \\033[33m%-" + targetNameSize + "s\\033[0m  %s\\n\", $$1, $$2}'\n"
-	result += "\t@printf '\\n\\033[1mVariables:\\033[0m\\n\\n'\n"
-	result += "\t@grep -E '^ifdef [A-Z_]+ .*?## .*$$' $(abspath $(lastword $(MAKEFILE_LIST))) \\\n"
-	result += "\t\t| sed 's/ifdef //' \\\n"
-	result += "\t\t| sort -h \\\n"
-	result += "\t\t| awk 'BEGIN {FS = \" .*?## \"}; {printf \"  \\033[32m%-" + optionNameSize + "s\\033[0m  %s\\n\", $$1, $$2}'\n"
-	result += "\t@echo ''\n"
-	result += "\t@printf '\\033[90mGenerated by GoMakeGen " + VER + "\\033[0m\\n\\n'\n\n"
-
-	return result
-}
+// outputDockerfile saves, prints or checks Dockerfile depending on options. It
+// returns false if existing Dockerfile is outdated.
+func outputDockerfile(makefile *generator.Makefile, output string) bool {
+	data, err := makefile.RenderDockerfile()
 
-// getGenerationComment returns comment with all used flags
-func (m *Makefile) getGenerationComment() string {
-	result := "# This Makefile generated by GoMakeGen " + VER + " using next command:\n"
-	result += "# gomakegen "
-
-	if m.GlideUsed {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_GLIDE))
-	}
-
-	if m.DepUsed {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_DEP))
-	}
-
-	if m.ModUsed {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_MOD))
-	}
-
-	if m.Strip {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_STRIP))
-	}
-
-	if m.Benchmark {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_BENCHMARK))
-	}
-
-	if m.Race {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_RACE))
-	}
-
-	if m.CGO {
-		result += fmt.Sprintf("--%s ", getOptionName(OPT_CGO))
-	}
-
-	result += ".\n"
-	result += "#\n"
-	result += "# More info: https://kaos.sh/gomakegen\n\n"
-
-	return result
-}
-
-// getDefaultVariables generates default variables definitions
-func (m *Makefile) getDefaultVariables() string {
-	var result string
-
-	result += "ifdef VERBOSE ## Print verbose information (Flag)\n"
-	result += "VERBOSE_FLAG = -v\n"
-	result += "endif\n\n"
-
-	result += "ifdef PROXY ## Force proxy usage for downloading dependencies (Flag)\n"
-	result += "export GOPROXY=https://proxy.golang.org/cached-only,direct\n"
-	result += "endif\n\n"
-
-	if m.CGO {
-		result += "export CGO_ENABLED=1\n\n"
-	} else {
-		result += "ifdef CGO ## Enable CGO usage (Flag)\n"
-		result += "export CGO_ENABLED=1\n"
-		result += "else\n"
-		result += "export CGO_ENABLED=0\n"
-		result += "endif\n\n"
+	if err != nil {
+		terminal.Error(err)
+		os.Exit(1)
 	}
 
-	result += "MAKEDIR = $(dir $(realpath $(firstword $(MAKEFILE_LIST))))\n"
-	result += "GITREV ?= $(shell test -s $(MAKEDIR)/.git && git rev-parse --short HEAD)\n\n"
-
-	return result
+	return outputFile(data, output, "Dockerfile")
 }
 
-// getLDFlags returns LDFLAGS for build command
-func (m *Makefile) getLDFlags() string {
-	var flags []string
-
-	if m.Strip {
-		flags = append(flags, "-s", "-w")
+// outputFile saves, prints or checks additional file of given kind depending
+// on options. It returns false if existing file is outdated.
+func outputFile(data []byte, output, kind string) bool {
+	switch {
+	case options.GetB(OPT_CHECK):
+		return checkMakefile(nil, data, output, kind)
+	case options.GetB(OPT_DRY_RUN):
+		os.Stdout.Write(data)
+	case options.GetB(OPT_DIFF):
+		printMakefileDiff(data, output, kind)
+	default:
+		exportFile(data, output, kind)
 	}
 
-	flags = append(flags, "-X main.gitrev=$(GITREV)")
-
-	return strings.Join(flags, " ")
+	return true
 }
 
-// ////////////////////////////////////////////////////////////////////////////////// //
-
-// getActionText generates command with action description
-func getActionText(cur, total int, text string) string {
-	if total <= 1 {
-		return fmtc.Sprintfn("\t@echo \"{c*}%s{!}\"", text)
-	}
-
-	var buf bytes.Buffer
+// exportFile writes rendered data of file with given kind
+func exportFile(data []byte, output, kind string) {
+	err := os.MkdirAll(path.Dir(output), 0755)
 
-	buf.WriteString("\t@echo \"")
-	buf.WriteString(fmtc.Sprintf("{g}%s{!}", strings.Repeat("•", cur)))
-
-	if cur != total {
-		buf.WriteString(fmtc.Sprintf("{s-}%s{!}", strings.Repeat("•", total-cur)))
+	if err == nil {
+		err = os.WriteFile(output, data, 0644)
 	}
 
-	buf.WriteString(fmtc.Sprintf(" {c*}%s{!}\"\n", text))
-
-	return buf.String()
-}
-
-// getGoVersion returns current go version
-func getGoVersion() version.Version {
-	cmd := exec.Command("go", "version")
-	output, err := cmd.Output()
-
 	if err != nil {
-		return version.Version{}
+		terminal.Error(err)
+		os.Exit(1)
 	}
 
-	rawVersion := strutil.ReadField(string(output), 2, false, ' ')
-	rawVersion = strutil.Exclude(rawVersion, "go")
-
-	ver, _ := version.Parse(rawVersion)
-
-	return ver
+	fmtc.Printfn("{g}%s successfully created as {g*}%s{!}", kind, output)
 }
 
 // getOptionName parses option name in options package notation
@@ -1303,11 +398,6 @@ func getOptionName(opt string) string {
 	return longOpt
 }
 
-// getSeparator returns separator
-func getSeparator() string {
-	return strings.Repeat("#", SEPARATOR_SIZE)
-}
-
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // genCompletion generates completion for different shells
@@ -1346,7 +436,19 @@ func genUsage() *usage.Info {
 	info.AddOption(OPT_BENCHMARK, "Add target to run benchmarks")
 	info.AddOption(OPT_RACE, "Add target to test race conditions")
 	info.AddOption(OPT_CGO, "Enable CGO usage")
+	info.AddOption(OPT_CROSS, "Add target for cross-compilation of binaries")
+	info.AddOption(OPT_RELEASE, "Add target for packing binaries into release archives")
+	info.AddOption(OPT_LINT, "Add target to run golangci-lint or staticcheck")
+	info.AddOption(OPT_GENERATE, "Run go generate before building binaries")
+	info.AddOption(OPT_DOCKER, "Generate Dockerfile and targets for building container image")
 	info.AddOption(OPT_OUTPUT, "Output file {s-}(Makefile by default){!}", "file")
+	info.AddOption(OPT_FORMAT, "Output format {s-}(make, taskfile or just){!}", "format")
+	info.AddOption(OPT_TEMPLATES, "Directory with custom templates", "dir")
+	info.AddOption(OPT_CI, "Generate CI configuration {s-}(github or gitlab){!}", "system")
+	info.AddOption(OPT_SKIP_INV, "Skip sources which can't be parsed")
+	info.AddOption(OPT_CHECK, "Check that existing Makefile is up to date")
+	info.AddOption(OPT_DRY_RUN, "Print generated Makefile instead of saving it")
+	info.AddOption(OPT_DIFF, "Print difference between existing and generated Makefile")
 	info.AddOption(OPT_NO_COLOR, "Disable colors in output")
 	info.AddOption(OPT_HELP, "Show this help message")
 	info.AddOption(OPT_VER, "Show version")
@@ -1365,6 +467,14 @@ func genUsage() *usage.Info {
 		"Generate makefile for github.com/profile/project and save as project.make",
 	)
 
+	info.AddExample(
+		"--check .", "Check that Makefile for project in current directory is up to date",
+	)
+
+	info.AddExample(
+		"--diff .", "Show changes which will be made to Makefile for project in current directory",
+	)
+
 	return info
 }
 
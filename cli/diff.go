@@ -0,0 +1,271 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/terminal"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DIFF_CONTEXT is number of context lines around changes
+const DIFF_CONTEXT = 3
+
+// MAX_DIFF_CELLS is maximum size of LCS table (about 8 MB). If changed part
+// of files is bigger, all changed lines are shown as removed and added.
+const MAX_DIFF_CELLS = 1_000_000
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// diffLine contains info about line in diff
+type diffLine struct {
+	Kind byte // ' ' - unchanged, '-' - removed, '+' - added
+	Text string
+}
+
+// diffHunk contains info about hunk in unified diff
+type diffHunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []diffLine
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// printMakefileDiff prints unified diff between existing file of given kind
+// and rendered data
+func printMakefileDiff(makefileData []byte, output, kind string) {
+	var curData []byte
+	var err error
+
+	if fsutil.IsExist(output) {
+		curData, err = os.ReadFile(output)
+
+		if err != nil {
+			terminal.Error("Can't read %s: %v", output, err)
+			os.Exit(1)
+		}
+	}
+
+	// Escape sequences depend on terminal used for generation, so they are
+	// ignored the same way as in check mode
+	curText := escSeqRegex.ReplaceAllString(string(curData), "")
+	newText := escSeqRegex.ReplaceAllString(string(makefileData), "")
+
+	hunks := getDiffHunks(diffLines(splitLines(curText), splitLines(newText)))
+
+	if len(hunks) == 0 {
+		fmtc.Printfn("{g}%s {g*}%s{g} is up to date{!}", kind, output)
+		return
+	}
+
+	fmtc.Printfn("{*}--- %s{!}", output)
+	fmtc.Printfn("{*}+++ %s (generated){!}", output)
+
+	for _, hunk := range hunks {
+		fmtc.Printfn(
+			"{c}@@ -%d,%d +%d,%d @@{!}",
+			hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines,
+		)
+
+		for _, line := range hunk.Lines {
+			printDiffLine(line)
+		}
+	}
+}
+
+// printDiffLine prints diff line with colors
+func printDiffLine(line diffLine) {
+	switch line.Kind {
+	case '+':
+		fmtc.Print("{g}")
+	case '-':
+		fmtc.Print("{r}")
+	}
+
+	// Line printed without fmtc because makefile data can contain braces
+	fmt.Print(string(line.Kind) + line.Text)
+	fmtc.Print("{!}\n")
+}
+
+// splitLines splits data to lines
+func splitLines(data string) []string {
+	if data == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+}
+
+// diffLines returns edit script for converting one slice of lines to another
+func diffLines(a, b []string) []diffLine {
+	var prefix, suffix int
+
+	// Common prefix and suffix are unchanged, so LCS table is built
+	// only for changed part of files
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-suffix-1] == b[len(b)-suffix-1] {
+		suffix++
+	}
+
+	var result []diffLine
+
+	for _, line := range a[:prefix] {
+		result = append(result, diffLine{' ', line})
+	}
+
+	result = append(result, diffChangedLines(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, diffLine{' ', line})
+	}
+
+	return result
+}
+
+// diffChangedLines returns edit script for changed part of files
+func diffChangedLines(a, b []string) []diffLine {
+	var result []diffLine
+
+	if (len(a)+1)*(len(b)+1) > MAX_DIFF_CELLS {
+		for _, line := range a {
+			result = append(result, diffLine{'-', line})
+		}
+
+		for _, line := range b {
+			result = append(result, diffLine{'+', line})
+		}
+
+		return result
+	}
+
+	lcs := make([][]int, len(a)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var i, j int
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			result = append(result, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, diffLine{'-', a[i]})
+			i++
+		default:
+			result = append(result, diffLine{'+', b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		result = append(result, diffLine{'-', a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		result = append(result, diffLine{'+', b[j]})
+	}
+
+	return result
+}
+
+// getDiffHunks groups changed lines to hunks with context
+func getDiffHunks(lines []diffLine) []*diffHunk {
+	var changes []int
+
+	for index, line := range lines {
+		if line.Kind != ' ' {
+			changes = append(changes, index)
+		}
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Line numbers in old and new data for every diff line
+	oldNums, newNums := make([]int, len(lines)), make([]int, len(lines))
+	oldNum, newNum := 1, 1
+
+	for index, line := range lines {
+		oldNums[index], newNums[index] = oldNum, newNum
+
+		if line.Kind != '+' {
+			oldNum++
+		}
+
+		if line.Kind != '-' {
+			newNum++
+		}
+	}
+
+	var result []*diffHunk
+
+	for i := 0; i < len(changes); {
+		start := max(0, changes[i]-DIFF_CONTEXT)
+		end := min(len(lines), changes[i]+DIFF_CONTEXT+1)
+
+		for i++; i < len(changes) && changes[i]-DIFF_CONTEXT <= end; i++ {
+			end = min(len(lines), changes[i]+DIFF_CONTEXT+1)
+		}
+
+		hunk := &diffHunk{
+			OldStart: oldNums[start],
+			NewStart: newNums[start],
+			Lines:    lines[start:end],
+		}
+
+		for _, line := range hunk.Lines {
+			if line.Kind != '+' {
+				hunk.OldLines++
+			}
+
+			if line.Kind != '-' {
+				hunk.NewLines++
+			}
+		}
+
+		if hunk.OldLines == 0 {
+			hunk.OldStart--
+		}
+
+		if hunk.NewLines == 0 {
+			hunk.NewStart--
+		}
+
+		result = append(result, hunk)
+	}
+
+	return result
+}
@@ -0,0 +1,113 @@
+package cli
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestDiffHunks(t *testing.T) {
+	tests := []struct {
+		name    string
+		old     []string
+		new     []string
+		headers []string
+	}{
+		{"no changes", numLines(1, 10), numLines(1, 10), nil},
+		{"new file", nil, numLines(1, 2), []string{"@@ -0,0 +1,2 @@"}},
+		{"removed file", numLines(1, 2), nil, []string{"@@ -1,2 +0,0 @@"}},
+		{
+			"insert only", numLines(1, 10),
+			slices.Concat(numLines(1, 5), []string{"x"}, numLines(6, 10)),
+			[]string{"@@ -3,6 +3,7 @@"},
+		},
+		{
+			"delete only", numLines(1, 10),
+			slices.Concat(numLines(1, 4), numLines(6, 10)),
+			[]string{"@@ -2,7 +2,6 @@"},
+		},
+		{
+			"changed middle", numLines(1, 10),
+			slices.Concat(numLines(1, 4), []string{"x"}, numLines(6, 10)),
+			[]string{"@@ -2,7 +2,7 @@"},
+		},
+		{
+			"multiple hunks", numLines(1, 20),
+			slices.Concat(numLines(1, 1), []string{"x"}, numLines(3, 17), []string{"y"}, numLines(19, 20)),
+			[]string{"@@ -1,5 +1,5 @@", "@@ -15,6 +15,6 @@"},
+		},
+		{
+			"merged hunks", numLines(1, 20),
+			slices.Concat(numLines(1, 4), []string{"x"}, numLines(6, 10), []string{"y"}, numLines(12, 20)),
+			[]string{"@@ -2,13 +2,13 @@"},
+		},
+	}
+
+	for _, test := range tests {
+		var headers []string
+
+		for _, hunk := range getDiffHunks(diffLines(test.old, test.new)) {
+			headers = append(headers, fmt.Sprintf(
+				"@@ -%d,%d +%d,%d @@",
+				hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines,
+			))
+		}
+
+		if !slices.Equal(headers, test.headers) {
+			t.Errorf("Wrong hunks for %q: %v (expected %v)", test.name, headers, test.headers)
+		}
+	}
+}
+
+func TestDiffLinesLimit(t *testing.T) {
+	old := numLines(1, 2001)
+	new := slices.Clone(old)
+
+	// Every second line is changed, so LCS table for changed part of
+	// files exceeds the limit
+	for i := 0; i < len(new); i += 2 {
+		new[i] = "x" + new[i]
+	}
+
+	lines := diffLines(
+		slices.Concat([]string{"a"}, old, []string{"z"}),
+		slices.Concat([]string{"a"}, new, []string{"z"}),
+	)
+
+	if len(lines) != 2+len(old)+len(new) {
+		t.Fatalf("Wrong number of diff lines: %d", len(lines))
+	}
+
+	if lines[0].Kind != ' ' || lines[len(lines)-1].Kind != ' ' {
+		t.Error("Common prefix and suffix aren't marked as unchanged")
+	}
+
+	for index, line := range lines[1 : len(lines)-1] {
+		if (index < len(old) && line.Kind != '-') || (index >= len(old) && line.Kind != '+') {
+			t.Fatalf("Changed lines aren't shown as removed and added (line %d)", index)
+		}
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// numLines returns lines with numbers from start to end
+func numLines(start, end int) []string {
+	var result []string
+
+	for i := start; i <= end; i++ {
+		result = append(result, strconv.Itoa(i))
+	}
+
+	return result
+}
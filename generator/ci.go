@@ -0,0 +1,155 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/version"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CI_COVERAGE_FILE is name of file with coverage data used in CI
+const CI_COVERAGE_FILE = "coverage.txt"
+
+// CI_TEST_REPORT is name of file with tests report used in CI
+const CI_TEST_REPORT = "report.xml"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// supportedCI contains all supported CI systems
+var supportedCI = []string{CI_GITHUB, CI_GITLAB}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RenderCI returns data of CI configuration
+func (m *Makefile) RenderCI() ([]byte, error) {
+	if m.CI == "" {
+		return nil, fmt.Errorf("CI system is not set")
+	}
+
+	tmpl, err := getTemplates("ci", m.Templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := execTemplate(tmpl, m.CI, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := m.getGenerationComment("configuration")
+	result += strings.Trim(data, "\n") + "\n"
+
+	return []byte(result), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getCITarget returns name of target which must be used in CI for given
+// target or empty string if there is no such target
+func (m *Makefile) getCITarget(target string) string {
+	if len(m.Modules) != 0 {
+		for _, info := range m.getModulesTargetsInfo() {
+			if info.Target == target {
+				return info.Name
+			}
+		}
+
+		// Only targets without aggregated version are run for root module
+		if target != "vet" && target != "lint" && target != "benchmark" {
+			return ""
+		}
+	}
+
+	switch {
+	case m.IsWorkspaceRoot:
+		return ""
+	case target == "all" && len(m.Binaries) == 0,
+		target == "deps" && len(m.BaseImports) == 0 && !m.ModUsed,
+		target == "test" && !m.HasTests,
+		target == "benchmark" && !m.Benchmark,
+		target == "lint" && (!m.Lint || m.Linter == ""):
+		return ""
+	}
+
+	return target
+}
+
+// getCICommand returns command for running target with given variables
+func (m *Makefile) getCICommand(target string, vars ...string) string {
+	vars = slices.DeleteFunc(vars, func(v string) bool { return v == "" })
+
+	switch m.Format {
+	case FORMAT_TASKFILE:
+		return strings.Join(append([]string{"task", target}, vars...), " ")
+	case FORMAT_JUST:
+		return strings.Join(append(append([]string{"just"}, vars...), target), " ")
+	}
+
+	return strings.Join(append([]string{"make", target}, vars...), " ")
+}
+
+// getCITestVars returns variables for running tests in CI
+func (m *Makefile) getCITestVars(coverage bool, report string) string {
+	var vars []string
+
+	// Race detector requires CGO
+	if m.Race && !m.CGO {
+		vars = append(vars, "CGO=1")
+	}
+
+	if report != "" {
+		vars = append(vars, "TEST_REPORT="+report)
+	}
+
+	if coverage {
+		vars = append(vars, "COVERAGE_FILE="+CI_COVERAGE_FILE)
+	}
+
+	return strings.Join(vars, " ")
+}
+
+// getCIGoVersions returns Go versions for running CI jobs
+func (m *Makefile) getCIGoVersions() []string {
+	ver, err := version.Parse(m.GoVersion)
+
+	if m.GoVersion == "" || err != nil {
+		return []string{"stable"}
+	}
+
+	return []string{fmt.Sprintf("%d.%d.x", ver.Major(), ver.Minor()), "stable"}
+}
+
+// getCIGoImage returns name of Docker image with Go
+func (m *Makefile) getCIGoImage() string {
+	ver, err := version.Parse(m.GoVersion)
+
+	if m.GoVersion == "" || err != nil {
+		return "golang:latest"
+	}
+
+	return fmt.Sprintf("golang:%d.%d", ver.Major(), ver.Minor())
+}
+
+// getCIOutput returns path to configuration file for given CI system
+func getCIOutput(ci string) string {
+	switch ci {
+	case CI_GITHUB:
+		return ".github/workflows/ci.yml"
+	case CI_GITLAB:
+		return ".gitlab-ci.yml"
+	}
+
+	return ""
+}
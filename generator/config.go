@@ -0,0 +1,236 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CONFIG_FILE is name of project configuration file
+const CONFIG_FILE = ".gomakegen.yml"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Config contains project configuration
+//
+// Options are applied with next precedence (from lowest to highest): options from
+// previously generated Makefile and options detected from project files,
+// configuration file, generation options. Boolean option set to false in
+// configuration file disables it, while generation options can only enable
+// options.
+type Config struct {
+	Output    string `yaml:"output"`
+	Format    string `yaml:"format"`
+	Templates string `yaml:"templates"`
+
+	CI         string `yaml:"ci"`
+	CICoverage bool   `yaml:"ci_coverage"`
+
+	DockerRuntime string `yaml:"docker_runtime"`
+
+	Glide     *bool `yaml:"glide"`
+	Dep       *bool `yaml:"dep"`
+	Mod       *bool `yaml:"mod"`
+	Strip     *bool `yaml:"strip"`
+	Benchmark *bool `yaml:"benchmark"`
+	Race      *bool `yaml:"race"`
+	CGO       *bool `yaml:"cgo"`
+	Cross     *bool `yaml:"cross"`
+	Release   *bool `yaml:"release"`
+	Lint      *bool `yaml:"lint"`
+	Generate  *bool `yaml:"generate"`
+	Docker    *bool `yaml:"docker"`
+
+	Exclude    []string          `yaml:"exclude"`
+	LDFlags    string            `yaml:"ldflags"`
+	InstallDir string            `yaml:"install_dir"`
+	PrintFuncs []string          `yaml:"printfuncs"`
+	Platforms  []string          `yaml:"platforms"`
+	Binaries   map[string]string `yaml:"binaries"`
+
+	GenerateTools map[string]string `yaml:"generate_tools"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// supportedFormats contains all supported output formats
+var supportedFormats = []string{FORMAT_MAKE, FORMAT_TASKFILE, FORMAT_JUST}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readConfig reads configuration file from source directory and merges it
+// with generation options
+func readConfig(dir string, options Options) (*Config, error) {
+	config := &Config{}
+	file := dir + "/" + CONFIG_FILE
+
+	if fsutil.IsExist(file) {
+		err := config.Read(file)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if options.Output != "" {
+		config.Output = options.Output
+	}
+
+	if options.Format != "" {
+		config.Format = options.Format
+	}
+
+	// Format isn't defined explicitly, so we use format of previously
+	// generated file
+	if config.Format == "" {
+		config.Format = getPreviousFormat(dir, config.Output)
+	}
+
+	if config.Format == "" {
+		config.Format = FORMAT_MAKE
+	}
+
+	if !slices.Contains(supportedFormats, config.Format) {
+		return nil, fmt.Errorf("Unknown output format %q", config.Format)
+	}
+
+	if config.Output == "" {
+		config.Output = getDefaultOutput(config.Format)
+	}
+
+	switch {
+	case options.Templates != "":
+		config.Templates = options.Templates
+	case config.Templates != "" && !path.IsAbs(config.Templates):
+		config.Templates = path.Join(dir, config.Templates)
+	}
+
+	return config, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Read reads configuration from given YAML file
+func (c *Config) Read(file string) error {
+	fd, err := os.Open(file)
+
+	if err != nil {
+		return fmt.Errorf("Can't read configuration file: %w", err)
+	}
+
+	defer fd.Close()
+
+	decoder := yaml.NewDecoder(fd)
+	decoder.KnownFields(true)
+
+	err = decoder.Decode(c)
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("Can't parse configuration file %s: %w", file, err)
+	}
+
+	return nil
+}
+
+// Apply applies configuration to makefile struct
+func (c *Config) Apply(m *Makefile) {
+	applyBool(&m.GlideUsed, c.Glide)
+	applyBool(&m.DepUsed, c.Dep)
+	applyBool(&m.ModUsed, c.Mod)
+	applyBool(&m.Strip, c.Strip)
+	applyBool(&m.Benchmark, c.Benchmark)
+	applyBool(&m.Race, c.Race)
+	applyBool(&m.CGO, c.CGO)
+	applyBool(&m.Cross, c.Cross)
+	applyBool(&m.Release, c.Release)
+	applyBool(&m.Lint, c.Lint)
+	applyBool(&m.Generate, c.Generate)
+	applyBool(&m.Docker, c.Docker)
+
+	if c.CI != "" {
+		m.CI = c.CI
+	}
+
+	m.CICoverage = c.CICoverage
+
+	if c.DockerRuntime != "" {
+		m.DockerRuntime = c.DockerRuntime
+	}
+
+	if c.LDFlags != "" {
+		m.LDFlags = c.LDFlags
+	}
+
+	if c.InstallDir != "" {
+		m.InstallDir = c.InstallDir
+	}
+
+	if c.PrintFuncs != nil {
+		m.PrintFuncs = c.PrintFuncs
+	}
+
+	if len(c.Platforms) != 0 {
+		m.Platforms = c.Platforms
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getDefaultOutput returns default name of output file for given format
+func getDefaultOutput(format string) string {
+	switch format {
+	case FORMAT_TASKFILE:
+		return "Taskfile.yml"
+	case FORMAT_JUST:
+		return "justfile"
+	}
+
+	return "Makefile"
+}
+
+// getPreviousFormat returns format of previously generated file with given name
+// or with default name for any format
+func getPreviousFormat(dir, output string) string {
+	files := []string{output}
+
+	if output == "" {
+		files = nil
+
+		for _, format := range supportedFormats {
+			files = append(files, getDefaultOutput(format))
+		}
+	}
+
+	for _, file := range files {
+		opts := extractOptionsFromMakefile(dir + "/" + file)
+
+		if opts != "" {
+			return getFormatFromOptions(opts)
+		}
+	}
+
+	return ""
+}
+
+// applyBool sets value of option if it is defined in configuration
+func applyBool(opt *bool, value *bool) {
+	if value != nil {
+		*opt = *value
+	}
+}
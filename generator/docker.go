@@ -0,0 +1,114 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/version"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// supportedDockerRuntimes contains all supported runtime images for Dockerfile
+var supportedDockerRuntimes = []string{DOCKER_RUNTIME_DISTROLESS, DOCKER_RUNTIME_SCRATCH}
+
+// majorVersionRegex is regexp for major version suffix of module path
+var majorVersionRegex = regexp.MustCompile(`^v[0-9]+$`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RenderDockerfile returns data of Dockerfile for building container image
+// with binaries
+func (m *Makefile) RenderDockerfile() ([]byte, error) {
+	if !m.Docker {
+		return nil, fmt.Errorf("Dockerfile generation is disabled")
+	}
+
+	tmpl, err := getTemplates("docker", m.Templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := execTemplate(tmpl, "dockerfile", m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := m.getGenerationComment(DOCKERFILE)
+	result += strings.Trim(data, "\n") + "\n"
+
+	return []byte(result), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getDockerGoImage returns name of image with Go used for building binaries
+func (m *Makefile) getDockerGoImage() string {
+	ver, err := version.Parse(m.GoVersion)
+
+	if m.GoVersion == "" || err != nil {
+		ver = getGoVersion()
+	}
+
+	if ver.IsZero() {
+		return "golang:latest"
+	}
+
+	return fmt.Sprintf("golang:%d.%d", ver.Major(), ver.Minor())
+}
+
+// getDockerRuntimeImage returns name of image used for running binaries
+func (m *Makefile) getDockerRuntimeImage() string {
+	switch {
+	case m.DockerRuntime == DOCKER_RUNTIME_SCRATCH:
+		return "scratch"
+	case m.CGO:
+		// Binaries built with CGO require libc
+		return "gcr.io/distroless/base-debian12:nonroot"
+	}
+
+	return "gcr.io/distroless/static-debian12:nonroot"
+}
+
+// getDockerImageName returns default name of container image
+func (m *Makefile) getDockerImageName() string {
+	name := m.PkgBase
+
+	if majorVersionRegex.MatchString(path.Base(name)) {
+		name = path.Dir(name)
+	}
+
+	return strings.ToLower(path.Base(name))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// checkDockerRuntime checks runtime image for Dockerfile and sets default
+// runtime if it is not set
+func checkDockerRuntime(m *Makefile) error {
+	if m.DockerRuntime == "" {
+		m.DockerRuntime = DOCKER_RUNTIME_DISTROLESS
+	}
+
+	if !slices.Contains(supportedDockerRuntimes, m.DockerRuntime) {
+		return fmt.Errorf("Unknown runtime image %q", m.DockerRuntime)
+	}
+
+	if m.CGO && m.DockerRuntime == DOCKER_RUNTIME_SCRATCH {
+		return fmt.Errorf("Binaries built with CGO can't be used with scratch image")
+	}
+
+	return nil
+}
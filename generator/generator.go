@@ -0,0 +1,1702 @@
+// Package generator provides methods for analyzing Go projects and generating
+// makefiles for them
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+	"github.com/essentialkaos/ek/v13/strutil"
+	"github.com/essentialkaos/ek/v13/version"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// VERSION is version of generator used in generated makefiles
+const VERSION = "3.3.3"
+
+// Names of options saved in header of generated makefile
+const (
+	OPTION_GLIDE     = "glide"
+	OPTION_DEP       = "dep"
+	OPTION_MOD       = "mod"
+	OPTION_STRIP     = "strip"
+	OPTION_BENCHMARK = "benchmark"
+	OPTION_RACE      = "race"
+	OPTION_CGO       = "cgo"
+	OPTION_CROSS     = "cross"
+	OPTION_RELEASE   = "release"
+	OPTION_LINT      = "lint"
+	OPTION_GENERATE  = "generate"
+	OPTION_FORMAT    = "format"
+	OPTION_CI        = "ci"
+	OPTION_DOCKER    = "docker"
+)
+
+// Supported output formats
+const (
+	FORMAT_MAKE     = "make"
+	FORMAT_TASKFILE = "taskfile"
+	FORMAT_JUST     = "just"
+)
+
+// Supported CI systems
+const (
+	CI_GITHUB = "github"
+	CI_GITLAB = "gitlab"
+)
+
+// Supported runtime images for Dockerfile
+const (
+	DOCKER_RUNTIME_DISTROLESS = "distroless"
+	DOCKER_RUNTIME_SCRATCH    = "scratch"
+)
+
+// DOCKERFILE is name of generated Dockerfile
+const DOCKERFILE = "Dockerfile"
+
+// SEPARATOR_SIZE is default separator size
+const SEPARATOR_SIZE = 80
+
+// MAX_FREE_TAGS is maximum number of custom build tags in build constraint which
+// combinations are checked for constraint satisfiability
+const MAX_FREE_TAGS = 10
+
+// Markers of section with custom user targets
+const (
+	CUSTOM_BEGIN = "# gomakegen:custom begin"
+	CUSTOM_END   = "# gomakegen:custom end"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Options contains generation options. Enabled options are merged with options
+// from configuration file and previously generated makefile, so boolean options
+// can only enable features, but not disable them.
+type Options struct {
+	Output    string // Name of makefile (overrides value from configuration file)
+	Format    string // Output format (overrides value from configuration file)
+	Templates string // Path to directory with custom templates (overrides value from configuration file)
+	CI        string // CI system for generating configuration (overrides value from configuration file)
+
+	Glide     bool // Use glide for dependency management
+	Dep       bool // Use dep for dependency management
+	Mod       bool // Use go modules for dependency management
+	Strip     bool // Strip binaries
+	Benchmark bool // Add target for running benchmarks
+	Race      bool // Add race detector to tests
+	CGO       bool // Enable CGO usage
+	Cross     bool // Add target for cross-compilation
+	Release   bool // Add target for building release archives
+	Lint      bool // Add target for running linter
+	Generate  bool // Run go:generate directives before build
+	Docker    bool // Generate Dockerfile and targets for building container image
+
+	SkipInvalid bool // Skip sources which can't be parsed instead of returning error
+}
+
+// ParseError contains info about source file parsing error
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+// ParseErrors is slice with all source files parsing errors
+type ParseErrors []ParseError
+
+// Makefile contains full info for makefile generation
+type Makefile struct {
+	Output    string
+	Format    string // Output format (make, taskfile or just)
+	Templates string // Path to directory with custom templates
+
+	CI         string // CI system for generating configuration (github or gitlab)
+	CIOutput   string // Path to CI configuration file
+	CICoverage bool   // Upload coverage data in CI
+
+	DockerRuntime string // Runtime image for Dockerfile (distroless or scratch)
+
+	ParseErrors ParseErrors // Errors of sources skipped due to parsing errors
+
+	BaseImports []string
+	TestImports []string
+	Binaries    []string
+
+	BinSources   map[string]string
+	ReleaseFiles []string
+	PrintFuncs   []string
+	Platforms    []string
+
+	GenerateCmds  []string
+	GenerateTools []string
+
+	FuzzPaths []string
+	TestPaths []string
+
+	FuzzTests   map[string][]string
+	TaggedTests map[string][]string
+
+	PkgBase    string
+	GoVersion  string   // Go version from go directive in go.mod
+	Requires   []string // Direct dependencies from require directives in go.mod
+	InstallDir string
+	LDFlags    string
+	Linter     string
+
+	CustomSection string
+	CustomTargets []string
+
+	Modules []*Module
+
+	MaxTargetNameSize int
+	MaxOptionNameSize int
+
+	HasTests         bool
+	HasCustomSection bool // Previous file contains markers of custom section
+	Benchmark        bool
+	Race             bool
+	Strip            bool
+	CGO              bool
+	Cross            bool
+	Release          bool
+	Lint             bool
+	Generate         bool
+	Docker           bool
+	HasSubpackages   bool
+	HasStableImports bool
+	IsWorkspace      bool // Directory contains go.work file
+	IsWorkspaceRoot  bool // Directory contains only nested modules
+
+	GlideUsed bool
+	DepUsed   bool
+	ModUsed   bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Paths for check package
+var checkPackageImports = []string{
+	"github.com/go-check/check",
+	"github.com/essentialkaos/check",
+	"gopkg.in/check.v1",
+}
+
+// Known values of GOOS
+var knownOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos",
+	"ios", "js", "linux", "nacl", "netbsd", "openbsd", "plan9", "solaris",
+	"wasip1", "windows", "zos",
+}
+
+// Values of GOOS for which "unix" build tag is set
+var unixOS = []string{
+	"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos",
+	"ios", "linux", "netbsd", "openbsd", "solaris",
+}
+
+// Known values of GOARCH
+var knownArch = []string{
+	"386", "amd64", "amd64p32", "arm", "armbe", "arm64", "arm64be", "loong64",
+	"mips", "mipsle", "mips64", "mips64le", "mips64p32", "mips64p32le", "ppc",
+	"ppc64", "ppc64le", "riscv", "riscv64", "s390", "s390x", "sparc", "sparc64",
+	"wasm",
+}
+
+// Other tags which are set by go tool or have special meaning
+var knownTags = []string{
+	"cgo", "gc", "gccgo", "unix", "ignore", "gofuzz",
+	"race", "msan", "asan", "purego", "boringcrypto",
+}
+
+// Package paths of well-known code generation tools
+var generateTools = map[string]string{
+	"stringer":      "golang.org/x/tools/cmd/stringer",
+	"goyacc":        "golang.org/x/tools/cmd/goyacc",
+	"mockgen":       "go.uber.org/mock/mockgen",
+	"moq":           "github.com/matryer/moq",
+	"counterfeiter": "github.com/maxbrunsfeld/counterfeiter/v6",
+	"enumer":        "github.com/dmarkham/enumer",
+	"easyjson":      "github.com/mailru/easyjson/easyjson",
+	"msgp":          "github.com/tinylib/msgp",
+	"wire":          "github.com/google/wire/cmd/wire",
+	"swag":          "github.com/swaggo/swag/cmd/swag",
+	"gqlgen":        "github.com/99designs/gqlgen",
+	"sqlc":          "github.com/sqlc-dev/sqlc/cmd/sqlc",
+	"templ":         "github.com/a-h/templ/cmd/templ",
+	"oapi-codegen":  "github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen",
+	"protoc-gen-go": "google.golang.org/protobuf/cmd/protoc-gen-go",
+}
+
+// Default list of platforms for cross-compilation
+var defaultPlatforms = []string{
+	"linux/amd64",
+	"linux/arm64",
+	"darwin/amd64",
+	"darwin/arm64",
+}
+
+// Default list of print-like functions for vet
+var defaultPrintFuncs = []string{
+	"LPrintf", "TLPrintf", "TPrintf",
+	"log.Debug", "log.Info", "log.Warn",
+	"log.Error", "log.Critical", "log.Print",
+}
+
+// Regexp for extracting targets names from custom section
+var customTargetRegex = regexp.MustCompile(`^([a-zA-Z0-9_][a-zA-Z0-9_ -]*):([^=]|$)`)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Analyze analyzes sources in given directory and returns makefile struct for
+// it. If directory contains nested modules, makefiles for them are available
+// in Modules field.
+func Analyze(dir string, options Options) (*Makefile, error) {
+	config, err := readConfig(dir, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	sources := fsutil.ListAllFiles(
+		dir, true,
+		fsutil.ListingFilter{
+			MatchPatterns: []string{"*.go"},
+			SizeGreater:   1, // Ignore empty files
+		},
+	)
+
+	modules := findModules(dir, config.Exclude)
+	moduleSources := splitModuleSources(sources, modules)
+
+	var subModules []*Module
+	var parseErrs ParseErrors
+
+	for _, moduleDir := range modules {
+		moduleConfig, err := readConfig(dir+"/"+moduleDir, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		makefile, err := processSources(moduleSources[moduleDir], dir+"/"+moduleDir, moduleConfig, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// CI configuration is generated only for root directory
+		makefile.CI, makefile.CIOutput = "", ""
+
+		parseErrs = append(parseErrs, makefile.ParseErrors...)
+		subModules = append(subModules, &Module{
+			Dir:      moduleDir,
+			Path:     makefile.PkgBase,
+			Makefile: makefile,
+		})
+	}
+
+	makefile, err := processSources(moduleSources["."], dir, config, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	parseErrs = append(parseErrs, makefile.ParseErrors...)
+
+	if len(parseErrs) != 0 && !options.SkipInvalid {
+		return nil, parseErrs
+	}
+
+	makefile.Modules = subModules
+	makefile.IsWorkspace = fsutil.IsExist(dir + "/go.work")
+	makefile.IsWorkspaceRoot = len(subModules) != 0 && !fsutil.IsExist(dir+"/go.mod")
+
+	if makefile.GoVersion == "" && makefile.IsWorkspace {
+		makefile.GoVersion = getGoModDirective(dir+"/go.work", "go")
+	}
+
+	return makefile, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// processSources filters sources and generates makefile struct for module
+// in given directory
+func processSources(sources []string, dir string, config *Config, options Options) (*Makefile, error) {
+	sources = filterSources(sources, config.Exclude)
+	sources, files, parseErrs := parseSources(sources, dir, config.Platforms)
+
+	makefile, err := generateMakefile(sources, files, dir, config, options)
+
+	if err != nil {
+		return nil, err
+	}
+
+	makefile.ParseErrors = parseErrs
+
+	return makefile, nil
+}
+
+// filterSources removes sources from vendor directory and excluded paths
+// from sources list
+func filterSources(sources, exclude []string) []string {
+	var result []string
+
+	for _, source := range sources {
+		if strings.HasPrefix(source, "vendor/") || isExcludedSource(source, exclude) {
+			continue
+		}
+
+		result = append(result, source)
+	}
+
+	return result
+}
+
+// parseSources parses sources and returns sources which can be parsed and built
+// at least for one of given platforms, map with parsed files and errors for all
+// buildable sources which can't be parsed
+func parseSources(sources []string, dir string, platforms []string) ([]string, map[string]*ast.File, ParseErrors) {
+	var result []string
+	var errs ParseErrors
+
+	if len(platforms) == 0 {
+		platforms = defaultPlatforms
+	}
+
+	fset := token.NewFileSet()
+	files := make(map[string]*ast.File)
+
+	for _, source := range sources {
+		f, err := parser.ParseFile(
+			fset, path.Join(dir, source), nil,
+			parser.ParseComments|parser.SkipObjectResolution,
+		)
+
+		// Parser returns partial AST for files with syntax errors, so sources
+		// excluded by build constraints are skipped without errors
+		if !isBuildableSource(source, getBuildConstraint(f), platforms) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, getParseErrors(path.Join(dir, source), err)...)
+			continue
+		}
+
+		result = append(result, source)
+		files[source] = f
+	}
+
+	return result, files, errs
+}
+
+// getParseErrors converts error returned by parser to slice with parse errors
+func getParseErrors(file string, err error) ParseErrors {
+	var errList scanner.ErrorList
+
+	if !errors.As(err, &errList) {
+		return ParseErrors{{File: file, Msg: err.Error()}}
+	}
+
+	var result ParseErrors
+
+	for _, e := range errList {
+		result = append(result, ParseError{
+			File:   e.Pos.Filename,
+			Line:   e.Pos.Line,
+			Column: e.Pos.Column,
+			Msg:    e.Msg,
+		})
+	}
+
+	return result
+}
+
+// generateMakefile collects imports, process options and generate makefile struct
+func generateMakefile(sources []string, files map[string]*ast.File, dir string, config *Config, options Options) (*Makefile, error) {
+	makefile := collectImports(sources, files, dir)
+
+	goVersion := getGoVersion()
+
+	makefile.Output = config.Output
+	makefile.Format = config.Format
+	makefile.Templates = config.Templates
+
+	// Check custom templates before analysis, so errors will be shown
+	// before any makefile is written
+	_, err := getTemplates(makefile.Format, makefile.Templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	applyOptionsFromMakefile(dir+"/"+config.Output, makefile)
+	applyCustomSectionFromMakefile(dir+"/"+config.Output, makefile)
+
+	// Dependency manager detected from project files can be disabled
+	// in configuration file
+	makefile.GlideUsed = makefile.GlideUsed || fsutil.IsExist(dir+"/glide.yaml")
+	makefile.DepUsed = makefile.DepUsed || fsutil.IsExist(dir+"/Gopkg.toml")
+	makefile.ModUsed = makefile.ModUsed || fsutil.IsExist(dir+"/go.mod")
+
+	if !goVersion.IsZero() && (goVersion.Major() > 1 || goVersion.Minor() > 17) {
+		makefile.ModUsed = true
+	}
+
+	config.Apply(makefile)
+
+	makefile.Benchmark = makefile.Benchmark || options.Benchmark
+	makefile.Race = makefile.Race || options.Race
+	makefile.CGO = makefile.CGO || options.CGO
+	makefile.Cross = makefile.Cross || options.Cross
+	makefile.Release = makefile.Release || options.Release
+	makefile.Lint = makefile.Lint || options.Lint
+	makefile.Generate = makefile.Generate || options.Generate
+	makefile.Docker = makefile.Docker || options.Docker
+	makefile.Strip = makefile.Strip || options.Strip
+	makefile.GlideUsed = makefile.GlideUsed || options.Glide
+	makefile.DepUsed = makefile.DepUsed || options.Dep
+	makefile.ModUsed = makefile.ModUsed || options.Mod
+
+	// Targets for cross-compilation and release archives are available
+	// only in Makefile
+	if makefile.Format != FORMAT_MAKE {
+		switch {
+		case makefile.Cross:
+			return nil, fmt.Errorf("Option %q is supported only with %q format", OPTION_CROSS, FORMAT_MAKE)
+		case makefile.Release:
+			return nil, fmt.Errorf("Option %q is supported only with %q format", OPTION_RELEASE, FORMAT_MAKE)
+		}
+	}
+
+	if makefile.Release {
+		makefile.ReleaseFiles = findReleaseFiles(dir)
+	}
+
+	if makefile.Lint {
+		makefile.Linter = getLinter(dir)
+	}
+
+	if options.CI != "" {
+		makefile.CI = options.CI
+	}
+
+	if makefile.CI != "" {
+		if !slices.Contains(supportedCI, makefile.CI) {
+			return nil, fmt.Errorf("Unknown CI system %q", makefile.CI)
+		}
+
+		makefile.CIOutput = getCIOutput(makefile.CI)
+	}
+
+	// Container image can be built only for binaries
+	makefile.Docker = makefile.Docker && len(makefile.Binaries) != 0
+
+	if makefile.Docker {
+		err = checkDockerRuntime(makefile)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	makefile.GenerateTools = getGenerateTools(makefile.GenerateCmds, config.GenerateTools)
+
+	makefile.HasStableImports = containsStableImports(makefile.BaseImports)
+	makefile.HasStableImports = makefile.HasStableImports || containsStableImports(makefile.TestImports)
+
+	err = makefile.Cleanup(dir, config.Binaries)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return makefile, nil
+}
+
+// getLinter returns package path of linter used for lint target. If project
+// contains golangci-lint configuration golangci-lint is used, otherwise
+// staticcheck.
+func getLinter(dir string) string {
+	for _, file := range []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"} {
+		if !fsutil.IsExist(dir + "/" + file) {
+			continue
+		}
+
+		if isGolangCILintV2Config(dir + "/" + file) {
+			return "github.com/golangci/golangci-lint/v2/cmd/golangci-lint"
+		}
+
+		return "github.com/golangci/golangci-lint/cmd/golangci-lint"
+	}
+
+	return "honnef.co/go/tools/cmd/staticcheck"
+}
+
+// isGolangCILintV2Config returns true if golangci-lint configuration has
+// version 2 format
+func isGolangCILintV2Config(file string) bool {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return false
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.ReplaceAll(line, " ", "")
+
+		switch line {
+		case `version:"2"`, `version:'2'`, `version:2`, // YAML
+			`version="2"`, `"version":"2",`, `"version":"2"`: // TOML and JSON
+			return true
+		}
+	}
+
+	return false
+}
+
+// findReleaseFiles returns slice with README and LICENSE files from root
+// directory of the project
+func findReleaseFiles(dir string) []string {
+	files := fsutil.List(
+		dir, true,
+		fsutil.ListingFilter{
+			MatchPatterns: []string{"README*", "LICENSE*"},
+		},
+	)
+
+	// Directories (e.g. "licenses") and special files can't be copied
+	// to archive with cp
+	files = slices.DeleteFunc(files, func(file string) bool {
+		return !fsutil.IsRegular(dir + "/" + file)
+	})
+
+	slices.Sort(files)
+
+	return files
+}
+
+// collectImports collects import from parsed source files and returns imports
+// for base sources, test sources and slice with binaries
+func collectImports(sources []string, files map[string]*ast.File, dir string) *Makefile {
+	baseSources, testSources := splitSources(sources)
+
+	baseImports, binaries, hasSubPkgs := extractBaseImports(baseSources, files)
+	testImports, testPaths, taggedTests := extractTestImports(testSources, files, dir)
+	fuzzPaths := collectFuzzPaths(baseSources, files)
+	fuzzTests := collectFuzzTests(testSources, files)
+
+	genCommands := collectGenerateCommands(sources, files)
+
+	return &Makefile{
+		BaseImports:    baseImports,
+		TestImports:    testImports,
+		FuzzPaths:      fuzzPaths,
+		FuzzTests:      fuzzTests,
+		GenerateCmds:   genCommands,
+		TestPaths:      testPaths,
+		TaggedTests:    taggedTests,
+		PkgBase:        getBasePkgPath(dir),
+		GoVersion:      getModuleGoVersion(dir + "/go.mod"),
+		Requires:       getModuleRequires(dir + "/go.mod"),
+		InstallDir:     "/usr/bin",
+		PrintFuncs:     defaultPrintFuncs,
+		Platforms:      defaultPlatforms,
+		Binaries:       binaries,
+		HasTests:       len(testPaths) != 0,
+		HasSubpackages: hasSubPkgs,
+	}
+}
+
+// splitSources splits sources to two slices - with base sources and test sources
+func splitSources(sources []string) ([]string, []string) {
+	if !hasTests(sources) {
+		return sources, nil
+	}
+
+	var bSources, tSources []string
+
+	for _, source := range sources {
+		if isTestSource(source) {
+			tSources = append(tSources, source)
+		} else {
+			bSources = append(bSources, source)
+		}
+	}
+
+	return bSources, tSources
+}
+
+// extractBaseImports extracts base imports from given source files
+func extractBaseImports(sources []string, files map[string]*ast.File) ([]string, []string, bool) {
+	importsMap := make(map[string]bool)
+	binaries := make([]string, 0)
+	hasSubPkgs := false
+
+	for _, source := range sources {
+		imports, isBinary := extractImports(files[source])
+
+		for _, path := range imports {
+			importsMap[path] = true
+		}
+
+		if isBinary {
+			binaries = appendBinary(binaries, source)
+		}
+
+		if !hasSubPkgs && strings.Contains(source, "/") {
+			hasSubPkgs = true
+		}
+	}
+
+	return importMapToSlice(importsMap), binaries, hasSubPkgs
+}
+
+// extractTestImports extracts test imports from given source files and returns
+// imports, paths with tests and paths with tests which require custom build tags
+// (integration, e2e…)
+func extractTestImports(sources []string, files map[string]*ast.File, dir string) ([]string, []string, map[string][]string) {
+	if len(sources) == 0 {
+		return nil, nil, nil
+	}
+
+	importsMap := make(map[string]bool)
+	testPaths := make(map[string]bool)
+	taggedPaths := make(map[string]map[string]bool)
+
+	for _, source := range sources {
+		imports, _ := extractImports(files[source])
+		basePath := path.Dir(source)
+
+		for _, path := range imports {
+			importsMap[path] = true
+		}
+
+		tags := getCustomBuildTags(getBuildConstraint(files[source]))
+
+		if len(tags) == 0 {
+			testPaths["./"+basePath] = true
+			continue
+		}
+
+		for _, tag := range tags {
+			if taggedPaths[tag] == nil {
+				taggedPaths[tag] = make(map[string]bool)
+			}
+
+			taggedPaths[tag]["./"+basePath] = true
+		}
+	}
+
+	var taggedTests map[string][]string
+
+	if len(taggedPaths) != 0 {
+		taggedTests = make(map[string][]string)
+
+		for tag, paths := range taggedPaths {
+			taggedTests[tag] = importMapToSlice(paths)
+		}
+	}
+
+	return importMapToSlice(importsMap), importMapToSlice(testPaths), taggedTests
+}
+
+// collectFuzzPaths collects paths with fuzz tests
+func collectFuzzPaths(sources []string, files map[string]*ast.File) []string {
+	var result []string
+
+	for _, source := range sources {
+		if hasFuzzTests(files[source]) {
+			result = append(result, path.Dir(source))
+		}
+	}
+
+	return result
+}
+
+// collectFuzzTests collects native fuzz tests (FuzzXxx functions) grouped by
+// package path
+func collectFuzzTests(sources []string, files map[string]*ast.File) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, source := range sources {
+		funcs := extractFuzzFuncs(files[source])
+
+		if len(funcs) == 0 {
+			continue
+		}
+
+		pkgPath := "./" + path.Dir(source)
+		result[pkgPath] = append(result[pkgPath], funcs...)
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+
+	for pkgPath := range result {
+		sort.Strings(result[pkgPath])
+	}
+
+	return result
+}
+
+// collectGenerateCommands collects commands from go:generate directives
+func collectGenerateCommands(sources []string, files map[string]*ast.File) []string {
+	var result []string
+
+	for _, source := range sources {
+		result = append(result, extractGenerateCommands(files[source])...)
+	}
+
+	return result
+}
+
+// getGenerateTools returns package paths of tools used in go:generate commands.
+// Custom tools map has higher priority than map with well-known tools.
+func getGenerateTools(commands []string, customTools map[string]string) []string {
+	var result []string
+
+	for _, cmd := range commands {
+		tool := getGenerateToolPkg(cmd, customTools)
+
+		if tool != "" && !slices.Contains(result, tool) {
+			result = append(result, tool)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// getGenerateToolPkg returns package path of tool used in go:generate command
+func getGenerateToolPkg(cmd string, customTools map[string]string) string {
+	fields := strutil.Fields(cmd)
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	// Tools executed with "go run" or "go tool" don't require installation
+	if fields[0] == "go" {
+		return ""
+	}
+
+	tool := path.Base(fields[0])
+
+	if customTools[tool] != "" {
+		return customTools[tool]
+	}
+
+	return generateTools[tool]
+}
+
+// cleanupImports removes internal packages and local imports
+func cleanupImports(imports []string, dir string) []string {
+	if len(imports) == 0 {
+		return nil
+	}
+
+	result := make(map[string]bool)
+	gopath := os.Getenv("GOPATH")
+	basePath := getBasePkgPath(dir)
+
+	for _, imp := range imports {
+		if !isExternalPackage(imp) {
+			continue
+		}
+
+		if imp == basePath || strings.HasPrefix(imp, basePath+"/") {
+			continue
+		}
+
+		result[getPackageRoot(imp, gopath)] = true
+	}
+
+	return importMapToSlice(result)
+}
+
+// appendBinary appends binary source to slice. Main files in root directory
+// are used as is, for main packages in subdirectories (cmd/foo, tools/bar)
+// the package directory is used.
+func appendBinary(binaries []string, source string) []string {
+	binDir := path.Dir(source)
+
+	if binDir == "." {
+		return append(binaries, source)
+	}
+
+	if strings.HasPrefix(binDir, "testdata") || strings.Contains(binDir, "/testdata") {
+		return binaries
+	}
+
+	if slices.Contains(binaries, binDir) {
+		return binaries
+	}
+
+	return append(binaries, binDir)
+}
+
+// cleanupBinaries converts binaries sources to names and returns map with
+// sources for build command. Custom names can be defined for binary names or
+// for sources (main file or package directory).
+func cleanupBinaries(binaries []string, names map[string]string) ([]string, map[string]string, error) {
+	var result []string
+
+	sources := make(map[string]string)
+
+	for _, bin := range binaries {
+		source := bin
+
+		if !strings.HasSuffix(bin, ".go") {
+			source = "./" + bin
+		}
+
+		name := getBinaryName(source)
+
+		switch {
+		case names[bin] != "":
+			name = names[bin]
+		case names[name] != "":
+			name = names[name]
+		}
+
+		if sources[name] != "" {
+			return nil, nil, fmt.Errorf(
+				"Binaries from %s and %s have the same name %q, use \"binaries\" option in configuration file to set custom name for one of them (e.g. \"%s: <name>\")",
+				sources[name], source, name, bin,
+			)
+		}
+
+		result = append(result, name)
+		sources[name] = source
+	}
+
+	return result, sources, nil
+}
+
+// getBinaryName returns name of binary built by "go build" from given source
+func getBinaryName(source string) string {
+	if strings.HasSuffix(source, ".go") {
+		return strings.TrimSuffix(source, ".go")
+	}
+
+	return path.Base(source)
+}
+
+// isExcludedSource returns true if source matches any of excluded paths
+func isExcludedSource(source string, exclude []string) bool {
+	for _, pattern := range exclude {
+		pattern = strings.Trim(pattern, "/")
+
+		if source == pattern || strings.HasPrefix(source, pattern+"/") {
+			return true
+		}
+
+		isMatch, _ := filepath.Match(pattern, source)
+
+		if isMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// extractImports returns slice with all imports in parsed source file
+func extractImports(f *ast.File) ([]string, bool) {
+	var result []string
+	var isBinary bool
+
+	for _, imp := range f.Imports {
+		if f.Name.String() == "main" {
+			isBinary = true
+		}
+
+		result = append(result, strings.Trim(imp.Path.Value, "\""))
+	}
+
+	return result, isBinary
+}
+
+// hasFuzzTest returns true if given parsed source contains go-fuzz tests
+func hasFuzzTests(f *ast.File) bool {
+	if len(f.Comments) == 0 {
+		return false
+	}
+
+	return strings.Contains(f.Comments[0].Text(), "+build gofuzz")
+}
+
+// extractFuzzFuncs returns names of native fuzz functions from parsed test source
+func extractFuzzFuncs(f *ast.File) []string {
+	testingPkg := getImportName(f, "testing")
+
+	if testingPkg == "" {
+		return nil
+	}
+
+	var result []string
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+
+		if !ok || fn.Recv != nil || !isFuzzFuncName(fn.Name.Name) {
+			continue
+		}
+
+		params := fn.Type.Params.List
+
+		if len(params) != 1 || len(params[0].Names) > 1 {
+			continue
+		}
+
+		if isPkgTypePointer(params[0].Type, testingPkg, "F") {
+			result = append(result, fn.Name.Name)
+		}
+	}
+
+	return result
+}
+
+// getImportName returns name used in file for package with given path
+func getImportName(f *ast.File, pkg string) string {
+	for _, imp := range f.Imports {
+		if strings.Trim(imp.Path.Value, "\"") != pkg {
+			continue
+		}
+
+		if imp.Name != nil {
+			return imp.Name.Name
+		}
+
+		return path.Base(pkg)
+	}
+
+	return ""
+}
+
+// isPkgTypePointer returns true if expression is pointer to given type
+// from package
+func isPkgTypePointer(expr ast.Expr, pkgName, typeName string) bool {
+	star, ok := expr.(*ast.StarExpr)
+
+	if !ok {
+		return false
+	}
+
+	sel, ok := star.X.(*ast.SelectorExpr)
+
+	if !ok || sel.Sel.Name != typeName {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+
+	return ok && ident.Name == pkgName
+}
+
+// isFuzzFuncName returns true if given name is valid name of fuzz function
+func isFuzzFuncName(name string) bool {
+	if !strings.HasPrefix(name, "Fuzz") {
+		return false
+	}
+
+	if len(name) == 4 {
+		return true
+	}
+
+	return !unicode.IsLower(rune(name[4]))
+}
+
+// extractGenerateCommands returns commands from go:generate directives in
+// comments of given parsed source file
+func extractGenerateCommands(f *ast.File) []string {
+	var result []string
+
+	for _, group := range f.Comments {
+		for _, comment := range group.List {
+			line := comment.Text
+
+			if !strings.HasPrefix(line, "//go:generate ") && !strings.HasPrefix(line, "//go:generate\t") {
+				continue
+			}
+
+			cmd := strings.TrimSpace(strings.TrimPrefix(line, "//go:generate"))
+
+			if cmd != "" {
+				result = append(result, cmd)
+			}
+		}
+	}
+
+	return result
+}
+
+// getBuildConstraint returns build constraint defined in given parsed source file
+func getBuildConstraint(f *ast.File) constraint.Expr {
+	if f == nil {
+		return nil
+	}
+
+	var plusExprs []constraint.Expr
+
+	for _, group := range f.Comments {
+		// Build constraints must appear before package clause
+		if group.Pos() >= f.Package {
+			break
+		}
+
+		for _, comment := range group.List {
+			line := comment.Text
+
+			switch {
+			case constraint.IsGoBuild(line):
+				expr, err := constraint.Parse(line)
+
+				if err == nil {
+					return expr
+				}
+
+			case constraint.IsPlusBuild(line):
+				expr, err := constraint.Parse(line)
+
+				if err == nil {
+					plusExprs = append(plusExprs, expr)
+				}
+			}
+		}
+	}
+
+	if len(plusExprs) == 0 {
+		return nil
+	}
+
+	result := plusExprs[0]
+
+	for _, expr := range plusExprs[1:] {
+		result = &constraint.AndExpr{X: result, Y: expr}
+	}
+
+	return result
+}
+
+// isBuildableSource returns true if source with given build constraint can be
+// built at least for one of given platforms
+func isBuildableSource(source string, expr constraint.Expr, platforms []string) bool {
+	for _, platform := range platforms {
+		goos, goarch, _ := strings.Cut(platform, "/")
+
+		if !isMatchFileName(source, goos, goarch) {
+			continue
+		}
+
+		if expr == nil || isSatisfiableConstraint(expr, goos, goarch) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isMatchFileName returns true if file name suffixes (_linux.go, _amd64.go…)
+// match given platform
+func isMatchFileName(source, goos, goarch string) bool {
+	name := strings.TrimSuffix(path.Base(source), ".go")
+	name = strings.TrimSuffix(name, "_test")
+
+	_, name, ok := strings.Cut(name, "_")
+
+	if !ok {
+		return true
+	}
+
+	parts := strings.Split(name, "_")
+	count := len(parts)
+
+	if count >= 2 && slices.Contains(knownOS, parts[count-2]) && slices.Contains(knownArch, parts[count-1]) {
+		return isMatchOS(parts[count-2], goos) && parts[count-1] == goarch
+	}
+
+	switch {
+	case slices.Contains(knownOS, parts[count-1]):
+		return isMatchOS(parts[count-1], goos)
+	case slices.Contains(knownArch, parts[count-1]):
+		return parts[count-1] == goarch
+	}
+
+	return true
+}
+
+// isSatisfiableConstraint returns true if build constraint can be satisfied on
+// given platform with some set of custom build tags
+func isSatisfiableConstraint(expr constraint.Expr, goos, goarch string) bool {
+	var freeTags []string
+
+	walkConstraintTags(expr, false, func(tag string, _ bool) {
+		if !isPlatformTag(tag) && !slices.Contains(freeTags, tag) {
+			freeTags = append(freeTags, tag)
+		}
+	})
+
+	// Number of combinations of custom tags grows exponentially, so constraint
+	// with too many custom tags is considered satisfiable. It's better to keep
+	// source which is never built than to lose imports and binaries from it.
+	if len(freeTags) > MAX_FREE_TAGS {
+		return true
+	}
+
+	for mask := 0; mask < 1<<len(freeTags); mask++ {
+		isSatisfied := expr.Eval(func(tag string) bool {
+			switch {
+			case tag == "ignore":
+				return false
+			case tag == "unix":
+				return slices.Contains(unixOS, goos)
+			case slices.Contains(knownOS, tag):
+				return isMatchOS(tag, goos)
+			case slices.Contains(knownArch, tag):
+				return tag == goarch
+			}
+
+			index := slices.Index(freeTags, tag)
+
+			return index != -1 && mask&(1<<index) != 0
+		})
+
+		if isSatisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPlatformTag returns true if tag value depends only on target platform
+func isPlatformTag(tag string) bool {
+	return tag == "ignore" || tag == "unix" ||
+		slices.Contains(knownOS, tag) ||
+		slices.Contains(knownArch, tag)
+}
+
+// isMatchOS returns true if OS from build constraint matches target OS
+func isMatchOS(tagOS, goos string) bool {
+	switch {
+	case tagOS == goos,
+		tagOS == "linux" && goos == "android",
+		tagOS == "darwin" && goos == "ios",
+		tagOS == "solaris" && goos == "illumos":
+		return true
+	}
+
+	return false
+}
+
+// getCustomBuildTags returns custom build tags (not GOOS, GOARCH, Go version
+// or other well-known tags) required by build constraint
+func getCustomBuildTags(expr constraint.Expr) []string {
+	if expr == nil {
+		return nil
+	}
+
+	var result []string
+
+	walkConstraintTags(expr, false, func(tag string, negated bool) {
+		if !negated && !isKnownBuildTag(tag) && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	})
+
+	sort.Strings(result)
+
+	return result
+}
+
+// walkConstraintTags walks over constraint expression and calls handler for
+// every tag with flag which shows that tag is negated
+func walkConstraintTags(expr constraint.Expr, negated bool, handler func(tag string, negated bool)) {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		handler(e.Tag, negated)
+	case *constraint.NotExpr:
+		walkConstraintTags(e.X, !negated, handler)
+	case *constraint.AndExpr:
+		walkConstraintTags(e.X, negated, handler)
+		walkConstraintTags(e.Y, negated, handler)
+	case *constraint.OrExpr:
+		walkConstraintTags(e.X, negated, handler)
+		walkConstraintTags(e.Y, negated, handler)
+	}
+}
+
+// isKnownBuildTag returns true if given tag is GOOS, GOARCH, Go version
+// or other tag which is set by go tool
+func isKnownBuildTag(tag string) bool {
+	switch {
+	case slices.Contains(knownOS, tag),
+		slices.Contains(knownArch, tag),
+		slices.Contains(knownTags, tag),
+		strings.HasPrefix(tag, "go1."),
+		strings.HasPrefix(tag, "goexperiment."):
+		return true
+	}
+
+	return false
+}
+
+// hasTests returns true if project has tests
+func hasTests(sources []string) bool {
+	for _, source := range sources {
+		if isTestSource(source) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTestSource returns true if given file is tests
+func isTestSource(source string) bool {
+	return strings.HasSuffix(source, "_test.go")
+}
+
+// getPackageRoot returns root for package
+func getPackageRoot(pkg, gopath string) string {
+	if isPackageRoot(gopath + "/src/" + pkg) {
+		return pkg
+	}
+
+	pkgSlice := strings.Split(pkg, "/")
+
+	for i := 2; i < len(pkgSlice); i++ {
+		path := strings.Join(pkgSlice[:i], "/")
+
+		if isPackageRoot(gopath + "/src/" + path) {
+			return path
+		}
+	}
+
+	return pkg
+}
+
+// isPackageRoot returns true if given path is root for package
+func isPackageRoot(path string) bool {
+	if !fsutil.IsExist(path + "/.git") {
+		return false
+	}
+
+	files := fsutil.List(path, true, fsutil.ListingFilter{MatchPatterns: []string{"*.go"}})
+
+	return len(files) != 0
+}
+
+// isExternalPackage returns true if given package is external
+func isExternalPackage(pkg string) bool {
+	pkgSlice := strings.Split(pkg, "/")
+
+	if len(pkgSlice) == 0 || !strings.Contains(pkgSlice[0], ".") {
+		return false
+	}
+	return true
+}
+
+// importMapToSlice converts map with package names to string slice
+func importMapToSlice(imports map[string]bool) []string {
+	if len(imports) == 0 {
+		return nil
+	}
+
+	var result []string
+
+	for path := range imports {
+		result = append(result, path)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// containsPackage returns true if imports contains given packages
+func containsPackage(imports []string, pkgs []string) bool {
+	for _, pkg := range pkgs {
+		if slices.Contains(imports, pkg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBasePkgPath returns base package path
+func getBasePkgPath(dir string) string {
+	modPath := getModulePath(dir + "/go.mod")
+
+	if modPath != "" {
+		return modPath
+	}
+
+	gopath, _ := filepath.EvalSymlinks(os.Getenv("GOPATH"))
+	absDir, _ := filepath.Abs(dir)
+	absDir, _ = filepath.EvalSymlinks(absDir)
+
+	return strutil.Exclude(absDir, gopath+"/src/")
+}
+
+// getModulePath extracts module path from module directive in go.mod file
+func getModulePath(file string) string {
+	return strings.Trim(getGoModDirective(file, "module"), "\"`")
+}
+
+// getModuleGoVersion extracts Go version from go directive in go.mod file
+func getModuleGoVersion(file string) string {
+	return getGoModDirective(file, "go")
+}
+
+// getModuleRequires extracts paths of direct dependencies from require
+// directives in go.mod file
+func getModuleRequires(file string) []string {
+	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+
+	if err != nil {
+		return nil
+	}
+
+	defer fd.Close()
+
+	var result []string
+	var isRequireBlock bool
+
+	s := bufio.NewScanner(fd)
+
+	for s.Scan() {
+		text, comment, _ := strings.Cut(s.Text(), "//")
+		text = strings.TrimSpace(text)
+
+		switch {
+		case text == "":
+			continue
+		case isRequireBlock && text == ")":
+			isRequireBlock = false
+			continue
+		case isRequireBlock:
+			// Module path is used as is
+		case text == "require (" || text == "require(":
+			isRequireBlock = true
+			continue
+		case strings.HasPrefix(text, "require ") || strings.HasPrefix(text, "require\t"):
+			text = strings.TrimSpace(text[8:])
+		default:
+			continue
+		}
+
+		if strings.TrimSpace(comment) == "indirect" {
+			continue
+		}
+
+		fields := strings.Fields(text)
+
+		if len(fields) != 0 {
+			result = append(result, strings.Trim(fields[0], "\"`"))
+		}
+	}
+
+	return result
+}
+
+// getGoModDirective returns value of directive with given name from go.mod file
+func getGoModDirective(file, name string) string {
+	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+
+	if err != nil {
+		return ""
+	}
+
+	defer fd.Close()
+
+	s := bufio.NewScanner(fd)
+
+	for s.Scan() {
+		text := strings.TrimSpace(s.Text())
+
+		if !strings.HasPrefix(text, name) {
+			continue
+		}
+
+		text = strings.TrimPrefix(text, name)
+
+		if text == "" || (text[0] != ' ' && text[0] != '\t') {
+			continue
+		}
+
+		text, _, _ = strings.Cut(text, "//")
+
+		return strings.TrimSpace(text)
+	}
+
+	return ""
+}
+
+// containsStableImports returns true if imports contains stable import services path
+func containsStableImports(imports []string) bool {
+	if len(imports) == 0 {
+		return false
+	}
+
+	for _, pkg := range imports {
+		if strings.HasPrefix(pkg, "gopkg.in") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyOptionsFromFile reads used options from previously generated Makefile
+// and applies it to makefile struct
+func applyOptionsFromMakefile(file string, m *Makefile) {
+	if !fsutil.IsExist(file) {
+		return
+	}
+
+	opts := extractOptionsFromMakefile(file)
+
+	if opts == "" {
+		return
+	}
+
+	fields := strutil.Fields(opts)
+
+	for index, opt := range fields {
+		switch strings.TrimLeft(opt, "-") {
+		case OPTION_GLIDE:
+			m.GlideUsed = true
+		case OPTION_DEP:
+			m.DepUsed = true
+		case OPTION_STRIP:
+			m.Strip = true
+		case OPTION_BENCHMARK:
+			m.Benchmark = true
+		case OPTION_RACE:
+			m.Race = true
+		case OPTION_CGO:
+			m.CGO = true
+		case OPTION_CROSS:
+			m.Cross = true
+		case OPTION_RELEASE:
+			m.Release = true
+		case OPTION_LINT:
+			m.Lint = true
+		case OPTION_GENERATE:
+			m.Generate = true
+		case OPTION_DOCKER:
+			m.Docker = true
+		case OPTION_CI:
+			if index+1 < len(fields) {
+				m.CI = fields[index+1]
+			}
+		}
+	}
+}
+
+// getFormatFromOptions returns output format from options of previously
+// generated Makefile
+func getFormatFromOptions(opts string) string {
+	fields := strutil.Fields(opts)
+
+	for index, opt := range fields {
+		if strings.TrimLeft(opt, "-") == OPTION_FORMAT && index+1 < len(fields) {
+			return fields[index+1]
+		}
+	}
+
+	return FORMAT_MAKE
+}
+
+// extractOptionsFromMakefile extracts options from previously generated Makefile
+func extractOptionsFromMakefile(file string) string {
+	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+
+	if err != nil {
+		return ""
+	}
+
+	defer fd.Close()
+
+	r := bufio.NewReader(fd)
+	s := bufio.NewScanner(r)
+
+	for s.Scan() {
+		text := s.Text()
+
+		if !strings.HasPrefix(text, "# gomakegen ") {
+			continue
+		}
+
+		return strutil.Exclude(text, "# gomakegen ")
+	}
+
+	return ""
+}
+
+// applyCustomSectionFromMakefile reads section with custom targets from previously
+// generated Makefile and applies it to makefile struct
+func applyCustomSectionFromMakefile(file string, m *Makefile) {
+	if !fsutil.IsExist(file) {
+		return
+	}
+
+	m.CustomSection, m.CustomTargets, m.HasCustomSection = extractCustomSection(file)
+}
+
+// extractCustomSection extracts section with custom targets from previously
+// generated Makefile and returns section data, names of targets and flag
+// which shows that file contains section markers
+func extractCustomSection(file string) (string, []string, bool) {
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		return "", nil, false
+	}
+
+	var section []string
+	var targets []string
+	var isCustom, hasSection bool
+
+	for _, line := range strings.Split(string(data), "\n") {
+		switch strings.TrimSpace(line) {
+		case CUSTOM_BEGIN:
+			isCustom, hasSection = true, true
+			continue
+		case CUSTOM_END:
+			isCustom = false
+			continue
+		}
+
+		if !isCustom {
+			continue
+		}
+
+		section = append(section, line)
+		match := customTargetRegex.FindStringSubmatch(line)
+
+		if match == nil {
+			continue
+		}
+
+		for _, target := range strutil.Fields(match[1]) {
+			if !slices.Contains(targets, target) {
+				targets = append(targets, target)
+			}
+		}
+	}
+
+	if len(section) == 0 {
+		return "", nil, hasSection
+	}
+
+	return strings.Join(section, "\n"), targets, hasSection
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cleanup cleans imports and converts binaries sources to names using given
+// map with custom names
+func (m *Makefile) Cleanup(dir string, names map[string]string) error {
+	var err error
+
+	m.BaseImports = cleanupImports(m.BaseImports, dir)
+	m.TestImports = cleanupImports(m.TestImports, dir)
+
+	m.Binaries, m.BinSources, err = cleanupBinaries(m.Binaries, names)
+
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(m.Binaries)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Error returns error message with position in source file
+func (e ParseError) Error() string {
+	if e.Line == 0 {
+		return e.File + ": " + e.Msg
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Msg)
+}
+
+// Error returns messages of all errors
+func (e ParseErrors) Error() string {
+	var result []string
+
+	for _, err := range e {
+		result = append(result, err.Error())
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// Files returns paths of all source files with errors
+func (e ParseErrors) Files() []string {
+	var result []string
+
+	for _, err := range e {
+		if !slices.Contains(result, err.File) {
+			result = append(result, err.File)
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getGoVersion returns current go version
+func getGoVersion() version.Version {
+	cmd := exec.Command("go", "version")
+	output, err := cmd.Output()
+
+	if err != nil {
+		return version.Version{}
+	}
+
+	rawVersion := strutil.ReadField(string(output), 2, false, ' ')
+	rawVersion = strutil.Exclude(rawVersion, "go")
+
+	ver, _ := version.Parse(rawVersion)
+
+	return ver
+}
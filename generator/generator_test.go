@@ -0,0 +1,844 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/essentialkaos/gomakegen/v3/internal/testutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// testGoMod is go.mod file of test project
+const testGoMod = "module example.com/app\n\ngo 1.22\n"
+
+// testMain is main package source of test project
+const testMain = "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println() }\n"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestTemplatesOverrideForFormat(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+		"tpl/make/fmt.tmpl": "fmt: ## Format source code\n" +
+			"\t@[[ -x \"$$(command -v gofumpt)\" ]] && gofumpt -w .\n\n",
+	})
+
+	m, err := Analyze(dir, Options{Templates: dir + "/tpl", CI: CI_GITHUB, Docker: true})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render makefile: %v", err)
+	}
+
+	if !strings.Contains(string(data), "gofumpt -w .") {
+		t.Error("Makefile doesn't contain overridden fmt target")
+	}
+
+	_, err = m.RenderCI()
+
+	if err != nil {
+		t.Errorf("Can't render CI configuration: %v", err)
+	}
+
+	_, err = m.RenderDockerfile()
+
+	if err != nil {
+		t.Errorf("Can't render Dockerfile: %v", err)
+	}
+}
+
+func TestPhonyDeclaration(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":       testGoMod,
+		"main.go":      testMain,
+		"main_test.go": "package main\n\nimport \"testing\"\n\nfunc TestApp(t *testing.T) {}\n",
+	})
+
+	m, err := Analyze(dir, Options{})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render makefile: %v", err)
+	}
+
+	if strings.Contains(string(data), ".PHONY =") {
+		t.Error("Makefile contains .PHONY variable instead of special target")
+	}
+
+	if !strings.Contains(string(data), "\n.PHONY: fmt vet all ") {
+		t.Fatal("Makefile doesn't contain valid .PHONY declaration")
+	}
+
+	if !strings.Contains(string(data), " test coverage ") {
+		t.Error("Target test with tests importing only standard library isn't declared as phony")
+	}
+}
+
+func TestInvalidSources(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":         testGoMod,
+		"main.go":        testMain,
+		"lib/broken.go":  "package lib\n\nfunc {\n",
+		"lib/ignored.go": "//go:build ignore\n\npackage lib\n\nfunc {\n",
+		"lib/windows.go": "//go:build windows && arm\n\npackage lib\n\nfunc {\n",
+		"lib/lib.go":     "package lib\n\nimport \"strings\"\n\nvar _ = strings.ToUpper\n",
+	})
+
+	testCases := []struct {
+		name        string
+		skipInvalid bool
+	}{
+		{"default", false},
+		{"skip-invalid", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := Analyze(dir, Options{SkipInvalid: tc.skipInvalid})
+
+			var parseErrs ParseErrors
+
+			if tc.skipInvalid {
+				if err != nil {
+					t.Fatalf("Can't analyze project with skipped sources: %v", err)
+				}
+
+				parseErrs = m.ParseErrors
+			} else {
+				if !errors.As(err, &parseErrs) {
+					t.Fatalf("Analyze must return parse errors, got %v", err)
+				}
+			}
+
+			if len(parseErrs) != 1 {
+				t.Fatalf("Expected 1 parse error, got %d: %v", len(parseErrs), parseErrs)
+			}
+
+			expected := filepath.Join(dir, "lib/broken.go") + ":3:6: expected 'IDENT', found '{'"
+
+			if parseErrs.Error() != expected {
+				t.Errorf("Unexpected error text %q (expected %q)", parseErrs.Error(), expected)
+			}
+
+			if !slices.Equal(parseErrs.Files(), []string{filepath.Join(dir, "lib/broken.go")}) {
+				t.Errorf("Unexpected files with errors: %v", parseErrs.Files())
+			}
+
+			if !tc.skipInvalid {
+				return
+			}
+
+			data, err := m.Render()
+
+			if err != nil {
+				t.Fatalf("Can't render makefile: %v", err)
+			}
+
+			if !strings.Contains(string(data), "\nall: main ") {
+				t.Error("Makefile doesn't contain target for building binary")
+			}
+		})
+	}
+}
+
+func TestBuildableSource(t *testing.T) {
+	manyTags := "t1 && t2 && t3 && t4 && t5 && t6 && t7 && t8 && t9 && t10"
+
+	testCases := []struct {
+		source     string
+		constraint string
+		platforms  []string
+		expected   bool
+	}{
+		{"file_linux.go", "", []string{"linux/amd64"}, true},
+		{"file_linux.go", "", []string{"android/arm64"}, true},
+		{"file_linux.go", "", []string{"windows/amd64", "darwin/arm64"}, false},
+		{"file_windows_amd64_test.go", "", []string{"windows/amd64"}, true},
+		{"file_windows_amd64_test.go", "", []string{"windows/arm64", "linux/amd64"}, false},
+		{"file_amd64.go", "", []string{"linux/arm64"}, false},
+		{"file_other.go", "", []string{"linux/arm64"}, true},
+		{"file.go", "//go:build ignore", defaultPlatforms, false},
+		{"file.go", "// +build linux,386 darwin", []string{"linux/386"}, true},
+		{"file.go", "// +build linux,386 darwin", []string{"linux/amd64"}, false},
+		{"file.go", "//go:build unix", []string{"linux/amd64"}, true},
+		{"file.go", "//go:build unix", []string{"windows/amd64"}, false},
+		{"file.go", "//go:build !linux && !darwin", []string{"linux/amd64", "darwin/arm64"}, false},
+		{"file.go", "//go:build !linux && !darwin", []string{"windows/amd64"}, true},
+		{"file.go", "//go:build linux && integration", []string{"linux/amd64"}, true},
+		{"file.go", "//go:build integration && !integration", []string{"linux/amd64"}, false},
+		{"file.go", "//go:build " + manyTags + " && !t10", []string{"linux/amd64"}, false},
+		{"file.go", "//go:build " + manyTags + " && t11 && !t11", []string{"linux/amd64"}, true},
+		{"file_windows.go", "//go:build " + manyTags + " && t11", []string{"linux/amd64"}, false},
+	}
+
+	for _, tc := range testCases {
+		src := "package lib\n"
+
+		if tc.constraint != "" {
+			src = tc.constraint + "\n\n" + src
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), tc.source, src, parser.ParseComments)
+
+		if err != nil {
+			t.Fatalf("Can't parse source: %v", err)
+		}
+
+		if isBuildableSource(tc.source, getBuildConstraint(f), tc.platforms) != tc.expected {
+			t.Errorf(
+				"isBuildableSource(%q, %q, %v) must return %t",
+				tc.source, tc.constraint, tc.platforms, tc.expected,
+			)
+		}
+	}
+}
+
+func TestBuildConstraint(t *testing.T) {
+	testCases := []struct {
+		src      string
+		expected string
+	}{
+		{"package lib\n", ""},
+		{"//go:build linux && !cgo\n\npackage lib\n", "linux && !cgo"},
+		{"// +build linux darwin\n// +build amd64\n\npackage lib\n", "(linux || darwin) && amd64"},
+		{"// Copyright\n\n//go:build e2e\n// +build e2e\n\npackage lib\n", "e2e"},
+		{"package lib\n\n//go:build e2e\n", ""},
+	}
+
+	for _, tc := range testCases {
+		f, err := parser.ParseFile(token.NewFileSet(), "file.go", tc.src, parser.ParseComments)
+
+		if err != nil {
+			t.Fatalf("Can't parse source: %v", err)
+		}
+
+		var result string
+
+		if expr := getBuildConstraint(f); expr != nil {
+			result = expr.String()
+		}
+
+		if result != tc.expected {
+			t.Errorf("Unexpected constraint for %q: %q (expected %q)", tc.src, result, tc.expected)
+		}
+	}
+}
+
+func TestCustomBuildTags(t *testing.T) {
+	testCases := []struct {
+		line     string
+		expected []string
+	}{
+		{"//go:build integration", []string{"integration"}},
+		{"//go:build linux && e2e", []string{"e2e"}},
+		{"//go:build !integration", nil},
+		{"//go:build (integration || e2e) && !short && go1.21", []string{"e2e", "integration"}},
+		{"//go:build cgo && unix && goexperiment.rangefunc", nil},
+		{"//go:build e2e && !(!slow)", []string{"e2e", "slow"}},
+		{"// +build integration,linux", []string{"integration"}},
+	}
+
+	for _, tc := range testCases {
+		expr, err := constraint.Parse(tc.line)
+
+		if err != nil {
+			t.Fatalf("Can't parse constraint %q: %v", tc.line, err)
+		}
+
+		result := getCustomBuildTags(expr)
+
+		if !slices.Equal(result, tc.expected) {
+			t.Errorf("Unexpected tags for %q: %v (expected %v)", tc.line, result, tc.expected)
+		}
+	}
+
+	if getCustomBuildTags(nil) != nil {
+		t.Error("Tags returned for empty constraint")
+	}
+}
+
+func TestJustfileTaggedAndFuzzTests(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+		"e2e/e2e_test.go": "//go:build e2e\n\npackage e2e\n\nimport \"testing\"\n\n" +
+			"func TestE2E(t *testing.T) {}\n",
+		"parser/parser_test.go": "package parser\n\nimport \"testing\"\n\n" +
+			"func FuzzParse(f *testing.F) {}\n",
+	})
+
+	m, err := Analyze(dir, Options{Format: FORMAT_JUST})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render justfile: %v", err)
+	}
+
+	for _, recipe := range []string{"\ntest-e2e:\n", "\nfuzz:\n", "\nTEST_REPORT := ", "\nFUZZ_TIME := "} {
+		if !strings.Contains(string(data), recipe) {
+			t.Errorf("Justfile doesn't contain %q", recipe)
+		}
+	}
+
+	if !strings.Contains(string(data), "-tags=e2e ./e2e") {
+		t.Error("Justfile doesn't run tests with e2e tag")
+	}
+
+	if !strings.Contains(string(data), "-fuzz='^FuzzParse$'") {
+		t.Error("Justfile doesn't run FuzzParse fuzz test")
+	}
+}
+
+func TestGenerateBeforeBuild(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":          testGoMod,
+		"cmd/app/main.go": "//go:generate stringer -type=Kind\n" + testMain,
+	})
+
+	m, err := Analyze(dir, Options{Generate: true, Cross: true, Release: true, Docker: true})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render makefile: %v", err)
+	}
+
+	for _, target := range []string{"\napp: generate\n", "\ndist: generate ", "\nrelease: generate "} {
+		if !strings.Contains(string(data), target) {
+			t.Errorf("Makefile doesn't contain %q", target)
+		}
+	}
+
+	data, err = m.RenderDockerfile()
+
+	if err != nil {
+		t.Fatalf("Can't render Dockerfile: %v", err)
+	}
+
+	genIndex := strings.Index(string(data), "\nRUN go generate ./...\n")
+	buildIndex := strings.Index(string(data), "\nRUN go build ")
+
+	if genIndex == -1 || genIndex > buildIndex {
+		t.Error("Dockerfile doesn't run go generate before build")
+	}
+}
+
+func TestDockerTargets(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+	})
+
+	targets := map[string][]string{
+		FORMAT_MAKE:     {"\ndocker-build: ", "\ndocker-push: "},
+		FORMAT_TASKFILE: {"\n  docker-build:\n", "\n  docker-push:\n"},
+		FORMAT_JUST:     {"\ndocker-build:\n", "\ndocker-push:\n"},
+	}
+
+	for format, names := range targets {
+		m, err := Analyze(dir, Options{Format: format, Docker: true})
+
+		if err != nil {
+			t.Fatalf("Can't analyze project: %v", err)
+		}
+
+		data, err := m.Render()
+
+		if err != nil {
+			t.Fatalf("Can't render %s: %v", format, err)
+		}
+
+		for _, name := range names {
+			if !strings.Contains(string(data), name) {
+				t.Errorf("Output for format %q doesn't contain %q", format, name)
+			}
+		}
+	}
+}
+
+func TestQualityTargets(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":       testGoMod,
+		"main.go":      testMain,
+		"main_test.go": "package main\n\nimport \"testing\"\n\nfunc TestApp(t *testing.T) {}\n",
+	})
+
+	commands := map[string]string{
+		FORMAT_TASKFILE: "run: task lint",
+		FORMAT_JUST:     "run: just lint",
+	}
+
+	targets := map[string][]string{
+		FORMAT_TASKFILE: {"\n  lint:\n", "\n  vuln:\n", "\n  coverage:\n", "\n  coverage-html:\n"},
+		FORMAT_JUST:     {"\nlint:\n", "\nvuln:\n", "\ncoverage: ", "\ncoverage-html: "},
+	}
+
+	for format, names := range targets {
+		m, err := Analyze(dir, Options{Format: format, Mod: true, Lint: true})
+
+		if err != nil {
+			t.Fatalf("Can't analyze project: %v", err)
+		}
+
+		data, err := m.Render()
+
+		if err != nil {
+			t.Fatalf("Can't render %s: %v", format, err)
+		}
+
+		for _, name := range names {
+			if !strings.Contains(string(data), name) {
+				t.Errorf("Output for format %q doesn't contain %q", format, name)
+			}
+		}
+
+		ci := renderCI(t, dir, Options{Format: format, Mod: true, Lint: true, CI: CI_GITHUB})
+
+		if !strings.Contains(ci, commands[format]) {
+			t.Errorf("Workflow for format %q doesn't run linter", format)
+		}
+	}
+}
+
+func TestMakeOnlyOptions(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+	})
+
+	for _, format := range []string{FORMAT_TASKFILE, FORMAT_JUST} {
+		for _, options := range []Options{
+			{Format: format, Cross: true},
+			{Format: format, Release: true},
+		} {
+			_, err := Analyze(dir, options)
+
+			if err == nil {
+				t.Errorf("Analyze for format %q doesn't return error for %+v", format, options)
+			}
+		}
+	}
+}
+
+func TestFormatFromPreviousFile(t *testing.T) {
+	for _, format := range []string{FORMAT_TASKFILE, FORMAT_JUST} {
+		dir := testutil.CreateProject(t, map[string]string{
+			"go.mod":  testGoMod,
+			"main.go": testMain,
+		})
+
+		m, err := Analyze(dir, Options{Format: format, Race: true})
+
+		if err != nil {
+			t.Fatalf("Can't analyze project: %v", err)
+		}
+
+		data, err := m.Render()
+
+		if err != nil {
+			t.Fatalf("Can't render %s: %v", format, err)
+		}
+
+		err = os.WriteFile(filepath.Join(dir, m.Output), data, 0644)
+
+		if err != nil {
+			t.Fatalf("Can't write %s: %v", m.Output, err)
+		}
+
+		for _, options := range []Options{{}, {Output: m.Output}} {
+			m, err = Analyze(dir, options)
+
+			if err != nil {
+				t.Fatalf("Can't analyze project: %v", err)
+			}
+
+			if m.Format != format || m.Output != getDefaultOutput(format) {
+				t.Errorf("Format %q (output %q) not restored, got %q (output %q)", format, getDefaultOutput(format), m.Format, m.Output)
+			}
+
+			if !m.Race {
+				t.Errorf("Options from %s not restored", getDefaultOutput(format))
+			}
+		}
+	}
+}
+
+func TestConfigDisablesOptions(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":    testGoMod,
+		"main.go":   testMain,
+		"Makefile":  "# gomakegen --race --strip .\n",
+		CONFIG_FILE: "mod: false\nrace: false\n",
+	})
+
+	m, err := Analyze(dir, Options{})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	if m.ModUsed {
+		t.Error("Option mod disabled in configuration file is enabled")
+	}
+
+	if m.Race {
+		t.Error("Option race disabled in configuration file is enabled")
+	}
+
+	if !m.Strip {
+		t.Error("Option strip from previously generated Makefile is disabled")
+	}
+
+	m, err = Analyze(dir, Options{Race: true})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	if !m.Race {
+		t.Error("Option race enabled by generation options is disabled")
+	}
+}
+
+func TestConfigInstallDir(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":    testGoMod,
+		"main.go":   testMain,
+		CONFIG_FILE: "install_dir: /usr/local/bin\n",
+	})
+
+	m, err := Analyze(dir, Options{})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render Makefile: %v", err)
+	}
+
+	if !strings.Contains(string(data), "\n\t@cp main /usr/local/bin/main\n") {
+		t.Error("Target install doesn't use directory from configuration file")
+	}
+}
+
+func TestBinariesWithSameName(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":               testGoMod,
+		"cmd/server/main.go":   testMain,
+		"tools/server/main.go": testMain,
+	})
+
+	_, err := Analyze(dir, Options{})
+
+	if err == nil {
+		t.Fatal("Binaries with the same name must return error")
+	}
+
+	for _, text := range []string{"./cmd/server", "./tools/server", `"binaries"`} {
+		if !strings.Contains(err.Error(), text) {
+			t.Errorf("Error %q doesn't contain %q", err.Error(), text)
+		}
+	}
+
+	err = os.WriteFile(filepath.Join(dir, CONFIG_FILE), []byte("binaries:\n  tools/server: server-tool\n"), 0644)
+
+	if err != nil {
+		t.Fatalf("Can't write configuration file: %v", err)
+	}
+
+	m, err := Analyze(dir, Options{})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	if strings.Join(m.Binaries, " ") != "server server-tool" {
+		t.Errorf("Unexpected binaries: %v", m.Binaries)
+	}
+
+	if m.BinSources["server-tool"] != "./tools/server" {
+		t.Errorf("Unexpected source of renamed binary: %q", m.BinSources["server-tool"])
+	}
+}
+
+func TestCleanCoverageFiles(t *testing.T) {
+	testFile := "package lib\n\nimport \"testing\"\n\nfunc TestLib(t *testing.T) {}\n"
+
+	projects := map[string]map[string]string{
+		"binary": {
+			"go.mod":      testGoMod,
+			"main.go":     testMain,
+			"lib/lib.go":  "package lib\n",
+			"lib_test.go": strings.Replace(testFile, "package lib", "package main", 1),
+		},
+		"library": {
+			"go.mod":          testGoMod,
+			"lib/lib.go":      "package lib\n",
+			"lib/lib_test.go": testFile,
+		},
+	}
+
+	commands := map[string]string{
+		FORMAT_MAKE:     "\n\t@rm -f coverage.out coverage.html\n",
+		FORMAT_TASKFILE: "\n      - rm -f coverage.out coverage.html\n",
+		FORMAT_JUST:     "\n    rm -f coverage.out coverage.html\n",
+	}
+
+	for name, files := range projects {
+		dir := testutil.CreateProject(t, files)
+
+		for format, command := range commands {
+			m, err := Analyze(dir, Options{Format: format})
+
+			if err != nil {
+				t.Fatalf("Can't analyze %s project: %v", name, err)
+			}
+
+			data, err := m.Render()
+
+			if err != nil {
+				t.Fatalf("Can't render %s for %s project: %v", format, name, err)
+			}
+
+			if !strings.Contains(string(data), command) {
+				t.Errorf("Target clean for format %q doesn't remove coverage files in %s project", format, name)
+			}
+		}
+	}
+}
+
+func TestGitHubWorkflowToolSetup(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+	})
+
+	steps := map[string]string{
+		FORMAT_MAKE:     "",
+		FORMAT_TASKFILE: "uses: arduino/setup-task@",
+		FORMAT_JUST:     "uses: extractions/setup-just@",
+	}
+
+	for format, step := range steps {
+		data := renderCI(t, dir, Options{Format: format, CI: CI_GITHUB})
+
+		switch {
+		case step != "" && !strings.Contains(data, step):
+			t.Errorf("Workflow for format %q doesn't contain %q step", format, step)
+		case step == "" && strings.Contains(data, "setup-task"),
+			step == "" && strings.Contains(data, "setup-just"):
+			t.Errorf("Workflow for format %q contains unnecessary setup step", format)
+		}
+	}
+}
+
+func TestGitLabPipelineToolSetup(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":  testGoMod,
+		"main.go": testMain,
+	})
+
+	commands := map[string]string{
+		FORMAT_MAKE:     "",
+		FORMAT_TASKFILE: "https://taskfile.dev/install.sh",
+		FORMAT_JUST:     "https://just.systems/install.sh",
+	}
+
+	for format, command := range commands {
+		data := renderCI(t, dir, Options{Format: format, CI: CI_GITLAB})
+
+		switch {
+		case command != "" && !strings.Contains(data, command):
+			t.Errorf("Pipeline for format %q doesn't install %s", format, format)
+		case command == "" && strings.Contains(data, "before_script"):
+			t.Errorf("Pipeline for format %q contains unnecessary before_script", format)
+		}
+	}
+}
+
+func TestGoModParsing(t *testing.T) {
+	tests := []struct {
+		data      string
+		module    string
+		goVersion string
+		requires  []string
+	}{
+		{
+			"// Module comment\nmodule \"example.com/app\" // comment\n\n" +
+				"godebug default=go1.21\ngo 1.22 // version\n\n" +
+				"require example.org/single v1.0.0\n\nrequire (\n" +
+				"\t// example.org/commented v1.0.0\n" +
+				"\texample.org/used v1.0.0\n" +
+				"\texample.org/other v1.0.0 // indirect\n" +
+				"\t\"example.org/quoted\" v1.2.0\n)\n\n" +
+				"replace example.org/used => ../used\n",
+			"example.com/app", "1.22",
+			[]string{"example.org/single", "example.org/used", "example.org/quoted"},
+		},
+		{
+			"module\texample.com/lib\n\ngo 1.21.0\n\nrequire(\n\texample.org/lib v1.0.0\n)\n",
+			"example.com/lib", "1.21.0",
+			[]string{"example.org/lib"},
+		},
+		{"", "", "", nil},
+	}
+
+	for index, test := range tests {
+		file := filepath.Join(testutil.CreateProject(t, map[string]string{"go.mod": test.data}), "go.mod")
+
+		if getModulePath(file) != test.module {
+			t.Errorf("Wrong module path for go.mod #%d: %q", index, getModulePath(file))
+		}
+
+		if getGoModDirective(file, "go") != test.goVersion {
+			t.Errorf("Wrong Go version for go.mod #%d: %q", index, getGoModDirective(file, "go"))
+		}
+
+		if !slices.Equal(getModuleRequires(file), test.requires) {
+			t.Errorf("Wrong requires for go.mod #%d: %v", index, getModuleRequires(file))
+		}
+	}
+}
+
+func TestCustomSectionRoundTrip(t *testing.T) {
+	sections := map[string][]string{
+		"my-target: ## My target\n\t@echo ok\n": {"my-target"},
+		"":                                      nil,
+	}
+
+	for section, targets := range sections {
+		custom := CUSTOM_BEGIN + "\n" + section + CUSTOM_END + "\n"
+
+		dir := testutil.CreateProject(t, map[string]string{
+			"go.mod":   testGoMod,
+			"main.go":  testMain,
+			"Makefile": "# gomakegen --mod .\n\n" + custom,
+		})
+
+		var prevData []byte
+
+		for range 2 {
+			m, err := Analyze(dir, Options{})
+
+			if err != nil {
+				t.Fatalf("Can't analyze project: %v", err)
+			}
+
+			if !slices.Equal(m.CustomTargets, targets) {
+				t.Errorf("Wrong custom targets %v (expected %v)", m.CustomTargets, targets)
+			}
+
+			data, err := m.Render()
+
+			if err != nil {
+				t.Fatalf("Can't render Makefile: %v", err)
+			}
+
+			if !strings.Contains(string(data), custom) {
+				t.Fatalf("Custom section %q isn't preserved", section)
+			}
+
+			if prevData != nil && string(prevData) != string(data) {
+				t.Errorf("Makefile with custom section %q changed after regeneration", section)
+			}
+
+			prevData = data
+
+			err = os.WriteFile(filepath.Join(dir, "Makefile"), data, 0644)
+
+			if err != nil {
+				t.Fatalf("Can't save Makefile: %v", err)
+			}
+		}
+	}
+}
+
+func TestReleaseFiles(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":                testGoMod,
+		"main.go":               testMain,
+		"README.md":             "# App\n",
+		"LICENSE":               "License\n",
+		"LICENSE's $HOME.txt":   "License\n",
+		"LICENSES/Apache-2.0":   "License\n",
+		"README.d/changelog.md": "Changelog\n",
+	})
+
+	m, err := Analyze(dir, Options{Release: true})
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.Render()
+
+	if err != nil {
+		t.Fatalf("Can't render Makefile: %v", err)
+	}
+
+	expected := `cp 'LICENSE' 'LICENSE'\''s $$HOME.txt' 'README.md' release/$$name/ ; \`
+
+	if !strings.Contains(string(data), expected) {
+		t.Errorf("Target release doesn't contain %q", expected)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderCI analyzes project in given directory and returns rendered
+// CI configuration
+func renderCI(t *testing.T, dir string, options Options) string {
+	t.Helper()
+
+	m, err := Analyze(dir, options)
+
+	if err != nil {
+		t.Fatalf("Can't analyze project: %v", err)
+	}
+
+	data, err := m.RenderCI()
+
+	if err != nil {
+		t.Fatalf("Can't render CI configuration: %v", err)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,64 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"text/template"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Names of templates with recipes in order of rendering
+var recipesTemplates = []string{
+	"build", "generate", "docker", "install", "deps", "vuln", "test", "test-tags",
+	"coverage", "fuzz", "benchmark", "mod", "fmt", "vet", "lint", "clean", "modules",
+}
+
+// Names of templates with recipes for root of workspace without module
+var workspaceRecipesTemplates = []string{"modules", "fmt"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderJustfile renders justfile (https://just.systems) using given templates
+func (m *Makefile) renderJustfile(tmpl *template.Template) ([]byte, error) {
+	variables, err := execTemplate(tmpl, "variables", m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := recipesTemplates
+
+	if m.IsWorkspaceRoot {
+		names = workspaceRecipesTemplates
+	}
+
+	blocks, err := execTemplateBlocks(tmpl, names, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blocks = append([]string{
+		strings.TrimRight(m.getGenerationComment("justfile"), "\n"),
+		strings.Trim(variables, "\n"),
+		m.getDefaultRecipe(),
+	}, blocks...)
+
+	if m.HasCustomSection {
+		blocks = append(blocks, strings.TrimRight(m.getCustomSection(), "\n"))
+	}
+
+	return []byte(strings.Join(blocks, "\n\n") + "\n"), nil
+}
+
+// getDefaultRecipe returns default recipe which prints list of all recipes
+func (m *Makefile) getDefaultRecipe() string {
+	return "# Show list of all recipes\ndefault:\n    @just --list"
+}
@@ -0,0 +1,385 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/essentialkaos/ek/v13/mathutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Names of templates with targets in order of rendering
+var targetsTemplates = []string{
+	"build", "generate", "dist", "release", "docker", "install", "uninstall",
+	"init", "deps", "deps-test", "update", "vendor", "vuln", "test",
+	"test-tags", "coverage", "gen-fuzz", "fuzz", "benchmark", "glide", "dep",
+	"mod", "fmt", "vet", "lint", "clean", "modules",
+}
+
+// Names of templates with targets for root of workspace without module
+var workspaceTemplates = []string{"modules", "fmt"}
+
+// optionRegex is regexp for extracting documented options from makefile data
+var optionRegex = regexp.MustCompile(`(?m)^ifdef ([A-Z_]+) .*## `)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Render returns makefile data in format defined by Format field
+func (m *Makefile) Render() ([]byte, error) {
+	tmpl, err := getTemplates(m.Format, m.Templates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch m.Format {
+	case FORMAT_TASKFILE:
+		return m.renderTaskfile(tmpl)
+	case FORMAT_JUST:
+		return m.renderJustfile(tmpl)
+	}
+
+	return m.renderMakefile(tmpl)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderMakefile renders makefile using given templates
+func (m *Makefile) renderMakefile(tmpl *template.Template) ([]byte, error) {
+	variables, err := execTemplate(tmpl, "variables", m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := m.getHeader(variables)
+	targets, err := m.getTargets(tmpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	m.MaxOptionNameSize = getMaxOptionNameSize(variables + targets)
+
+	help, err := execTemplate(tmpl, "help", m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result string
+
+	result += header
+	result += targets
+	result += m.getCustomSection()
+	result += help
+	result += getSeparator() + "\n"
+
+	return []byte(result), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getHeader returns header data
+func (m *Makefile) getHeader(variables string) string {
+	var result string
+
+	result += getSeparator() + "\n\n"
+	result += m.getGenerationComment("Makefile")
+	result += getSeparator() + "\n\n"
+	result += variables
+	result += getSeparator() + "\n\n"
+	result += m.getDefaultGoal() + "\n"
+	result += m.getPhony() + "\n"
+	result += getSeparator() + "\n\n"
+
+	return result
+}
+
+// getTargets returns targets data
+func (m *Makefile) getTargets(tmpl *template.Template) (string, error) {
+	if m.IsWorkspaceRoot {
+		return execTemplates(tmpl, workspaceTemplates, m)
+	}
+
+	return execTemplates(tmpl, targetsTemplates, m)
+}
+
+// codebeat:disable[ABC]
+
+// getPhony returns PHONY part of makefile
+func (m *Makefile) getPhony() string {
+	if m.IsWorkspaceRoot {
+		return m.getWorkspacePhony()
+	}
+
+	phony := []string{"fmt", "vet"}
+
+	if len(m.Binaries) != 0 {
+		phony = append(phony, "all", "install", "uninstall", "clean")
+
+		if m.Cross {
+			phony = append(phony, "dist")
+		}
+
+		if m.Release {
+			phony = append(phony, "release")
+		}
+
+		if m.Docker {
+			phony = append(phony, "docker-build", "docker-push")
+		}
+	}
+
+	if len(m.BaseImports) != 0 || m.ModUsed {
+		phony = append(phony, "deps", "update")
+	}
+
+	if m.ModUsed {
+		phony = append(phony, "vuln")
+	}
+
+	if m.HasTests {
+		phony = append(phony, "test", "coverage", "coverage-html", "coverage-profile")
+
+		// Target clean removes coverage files even if there are no binaries
+		if len(m.Binaries) == 0 {
+			phony = append(phony, "clean")
+		}
+	}
+
+	for _, tag := range slices.Sorted(maps.Keys(m.TaggedTests)) {
+		phony = append(phony, "test-"+tag)
+	}
+
+	if !m.GlideUsed && !m.DepUsed && !m.ModUsed {
+		if len(m.TestImports) != 0 {
+			phony = append(phony, "deps-test")
+		}
+	} else {
+		phony = append(phony, "init", "vendor")
+	}
+
+	if len(m.FuzzPaths) != 0 {
+		phony = append(phony, "gen-fuzz")
+	}
+
+	if len(m.FuzzTests) != 0 {
+		phony = append(phony, "fuzz")
+	}
+
+	if m.Benchmark {
+		phony = append(phony, "benchmark")
+	}
+
+	if m.Lint {
+		phony = append(phony, "lint")
+	}
+
+	if len(m.GenerateCmds) != 0 {
+		phony = append(phony, "generate")
+
+		if len(m.GenerateTools) != 0 {
+			phony = append(phony, "generate-deps")
+		}
+	}
+
+	phony = append(phony, m.getModulesPhony()...)
+
+	for _, target := range phony {
+		m.MaxTargetNameSize = mathutil.Max(m.MaxTargetNameSize, len(target))
+	}
+
+	if m.GlideUsed {
+		phony = append(phony, "glide-create", "glide-install", "glide-update")
+	}
+
+	if m.DepUsed {
+		phony = append(phony, "dep-init", "dep-update", "dep-vendor")
+	}
+
+	if m.ModUsed {
+		phony = append(phony, "tidy", "mod-init", "mod-update", "mod-download", "mod-vendor")
+	}
+
+	for _, target := range m.CustomTargets {
+		if !slices.Contains(phony, target) {
+			phony = append(phony, target)
+		}
+
+		m.MaxTargetNameSize = mathutil.Max(m.MaxTargetNameSize, len(target))
+	}
+
+	phony = append(phony, "help")
+
+	return ".PHONY: " + strings.Join(phony, " ") + "\n"
+}
+
+// codebeat:enable[ABC]
+
+// getWorkspacePhony returns PHONY part of makefile for directory which
+// contains only nested modules
+func (m *Makefile) getWorkspacePhony() string {
+	phony := append([]string{"fmt"}, m.getModulesPhony()...)
+
+	for _, target := range m.CustomTargets {
+		if !slices.Contains(phony, target) {
+			phony = append(phony, target)
+		}
+	}
+
+	for _, target := range phony {
+		m.MaxTargetNameSize = mathutil.Max(m.MaxTargetNameSize, len(target))
+	}
+
+	phony = append(phony, "help")
+
+	return ".PHONY: " + strings.Join(phony, " ") + "\n"
+}
+
+// getDefaultGoal returns DEFAULT_GOAL part of makefile
+func (m *Makefile) getDefaultGoal() string {
+	return ".DEFAULT_GOAL := help"
+}
+
+// getCustomSection returns section with custom user targets
+func (m *Makefile) getCustomSection() string {
+	if !m.HasCustomSection {
+		return ""
+	}
+
+	result := CUSTOM_BEGIN + "\n"
+
+	if m.CustomSection != "" {
+		result += m.CustomSection + "\n"
+	}
+
+	result += CUSTOM_END + "\n\n"
+
+	return result
+}
+
+// getGenerationComment returns comment with all used flags
+func (m *Makefile) getGenerationComment(kind string) string {
+	result := "# This " + kind + " generated by GoMakeGen " + VERSION + " using next command:\n"
+	result += "# gomakegen "
+
+	if m.GlideUsed {
+		result += fmt.Sprintf("--%s ", OPTION_GLIDE)
+	}
+
+	if m.DepUsed {
+		result += fmt.Sprintf("--%s ", OPTION_DEP)
+	}
+
+	if m.ModUsed {
+		result += fmt.Sprintf("--%s ", OPTION_MOD)
+	}
+
+	if m.Strip {
+		result += fmt.Sprintf("--%s ", OPTION_STRIP)
+	}
+
+	if m.Benchmark {
+		result += fmt.Sprintf("--%s ", OPTION_BENCHMARK)
+	}
+
+	if m.Race {
+		result += fmt.Sprintf("--%s ", OPTION_RACE)
+	}
+
+	if m.CGO {
+		result += fmt.Sprintf("--%s ", OPTION_CGO)
+	}
+
+	if m.Cross {
+		result += fmt.Sprintf("--%s ", OPTION_CROSS)
+	}
+
+	if m.Release {
+		result += fmt.Sprintf("--%s ", OPTION_RELEASE)
+	}
+
+	if m.Lint {
+		result += fmt.Sprintf("--%s ", OPTION_LINT)
+	}
+
+	if m.Generate {
+		result += fmt.Sprintf("--%s ", OPTION_GENERATE)
+	}
+
+	if m.Docker {
+		result += fmt.Sprintf("--%s ", OPTION_DOCKER)
+	}
+
+	if m.Format != "" && m.Format != FORMAT_MAKE {
+		result += fmt.Sprintf("--%s %s ", OPTION_FORMAT, m.Format)
+	}
+
+	if m.CI != "" {
+		result += fmt.Sprintf("--%s %s ", OPTION_CI, m.CI)
+	}
+
+	result += ".\n"
+	result += "#\n"
+	result += "# More info: https://kaos.sh/gomakegen\n\n"
+
+	return result
+}
+
+// getTestFlags returns base flags for running tests
+func (m *Makefile) getTestFlags() string {
+	if m.Race {
+		return "$(VERBOSE_FLAG) -race -covermode=atomic"
+	}
+
+	return "$(VERBOSE_FLAG) -covermode=count"
+}
+
+// getLDFlags returns LDFLAGS for build command with given reference to
+// git revision variable
+func (m *Makefile) getLDFlags(strip bool, gitrev string) string {
+	var flags []string
+
+	if strip {
+		flags = append(flags, "-s", "-w")
+	}
+
+	flags = append(flags, "-X main.gitrev="+gitrev)
+
+	if m.LDFlags != "" {
+		flags = append(flags, m.LDFlags)
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getMaxOptionNameSize returns size of the longest documented option name
+func getMaxOptionNameSize(data string) int {
+	var result int
+
+	for _, match := range optionRegex.FindAllStringSubmatch(data, -1) {
+		result = mathutil.Max(result, len(match[1]))
+	}
+
+	return result
+}
+
+// getSeparator returns separator
+func getSeparator() string {
+	return strings.Repeat("#", SEPARATOR_SIZE)
+}
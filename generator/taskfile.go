@@ -0,0 +1,65 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"text/template"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Names of templates with tasks in order of rendering
+var tasksTemplates = []string{
+	"build", "generate", "docker", "deps", "vuln", "test", "test-tags",
+	"coverage", "fuzz", "benchmark", "mod", "fmt", "vet", "lint", "clean",
+	"modules",
+}
+
+// Names of templates with tasks for root of workspace without module
+var workspaceTasksTemplates = []string{"modules", "fmt"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderTaskfile renders Taskfile (https://taskfile.dev) using given templates
+func (m *Makefile) renderTaskfile(tmpl *template.Template) ([]byte, error) {
+	variables, err := execTemplate(tmpl, "variables", m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := tasksTemplates
+
+	if m.IsWorkspaceRoot {
+		names = workspaceTasksTemplates
+	}
+
+	blocks, err := execTemplateBlocks(tmpl, names, m)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blocks = append([]string{
+		strings.TrimRight(m.getGenerationComment("Taskfile"), "\n"),
+		strings.Trim(variables, "\n"),
+		"tasks:\n" + m.getDefaultTask(),
+	}, blocks...)
+
+	if m.HasCustomSection {
+		blocks = append(blocks, strings.TrimRight(m.getCustomSection(), "\n"))
+	}
+
+	return []byte(strings.Join(blocks, "\n\n") + "\n"), nil
+}
+
+// getDefaultTask returns default task which prints list of all tasks
+func (m *Makefile) getDefaultTask() string {
+	return "  default:\n    cmds:\n      - task --list\n    silent: true"
+}
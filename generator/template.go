@@ -0,0 +1,262 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/essentialkaos/ek/v13/fmtc"
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// TEMPLATE_EXT is extension of template files
+const TEMPLATE_EXT = ".tmpl"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// modulesTargetInfo contains info about target which runs command in modules
+// and list of these modules
+type modulesTargetInfo struct {
+	Name    string
+	Target  string
+	Desc    string
+	Action  string
+	Modules []*Module
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+//go:embed templates/*/*.tmpl
+var templatesFS embed.FS
+
+// templateFuncs contains functions available in templates
+var templateFuncs = template.FuncMap{
+	"action":          getActionText,
+	"join":            strings.Join,
+	"quote":           getQuotedArgs,
+	"replace":         strings.ReplaceAll,
+	"base":            path.Base,
+	"inc":             func(i int) int { return i + 1 },
+	"version":         func() string { return VERSION },
+	"sortedKeys":      getSortedKeys,
+	"fuzzCount":       getFuzzCount,
+	"hasCheckPackage": func(imports []string) bool { return containsPackage(imports, checkPackageImports) },
+	"ldflags":         func(m *Makefile, strip bool, gitrev string) string { return m.getLDFlags(strip, gitrev) },
+	"testFlags":       func(m *Makefile) string { return m.getTestFlags() },
+	"testTargets":     func(m *Makefile) string { return m.getTestTargets() },
+	"binSource":       func(m *Makefile, bin string) string { return m.getBinSource(bin) },
+	"modulesTargets":  func(m *Makefile) []modulesTargetInfo { return m.getModulesTargetsInfo() },
+	"ciTarget":        func(m *Makefile, target string) string { return m.getCITarget(target) },
+	"ciCommand":       func(m *Makefile, target string, vars ...string) string { return m.getCICommand(target, vars...) },
+	"ciTestVars":      func(m *Makefile, coverage bool, report string) string { return m.getCITestVars(coverage, report) },
+	"ciGoVersions":    func(m *Makefile) []string { return m.getCIGoVersions() },
+	"ciGoImage":       func(m *Makefile) string { return m.getCIGoImage() },
+	"ciCoverageFile":  func() string { return CI_COVERAGE_FILE },
+	"ciTestReport":    func() string { return CI_TEST_REPORT },
+	"dockerGoImage":   func(m *Makefile) string { return m.getDockerGoImage() },
+	"dockerRuntime":   func(m *Makefile) string { return m.getDockerRuntimeImage() },
+	"dockerImageName": func(m *Makefile) string { return m.getDockerImageName() },
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTemplates returns embedded templates for given format overridden by
+// templates from subdirectory of given directory with the same name as format
+func getTemplates(format, dir string) (*template.Template, error) {
+	if format == "" {
+		format = FORMAT_MAKE
+	}
+
+	tmpl := template.New("").Funcs(templateFuncs)
+
+	// Taskfile and justfile use {{ }} for variables, so we use different
+	// delimiters for our templates
+	if format != FORMAT_MAKE {
+		tmpl.Delims("[[", "]]")
+	}
+
+	tmpl, err := tmpl.ParseFS(templatesFS, "templates/"+format+"/*"+TEMPLATE_EXT)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse embedded templates: %w", err)
+	}
+
+	if dir == "" {
+		return tmpl, nil
+	}
+
+	err = fsutil.ValidatePerms("DRX", dir)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't use directory with templates: %w", err)
+	}
+
+	// Every format has its own set of templates with its own delimiters, so
+	// overrides for each format are stored in separate subdirectory
+	dir += "/" + format
+
+	if !fsutil.IsDir(dir) {
+		return tmpl, nil
+	}
+
+	for _, file := range fsutil.List(dir, true, fsutil.ListingFilter{MatchPatterns: []string{"*" + TEMPLATE_EXT}}) {
+		data, err := os.ReadFile(dir + "/" + file)
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't read template: %w", err)
+		}
+
+		_, err = tmpl.New(file).Parse(string(data))
+
+		if err != nil {
+			return nil, fmt.Errorf("Can't parse template %s: %w", dir+"/"+file, err)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// execTemplate executes template with given name and returns result
+func execTemplate(tmpl *template.Template, name string, m *Makefile) (string, error) {
+	var buf bytes.Buffer
+
+	err := tmpl.ExecuteTemplate(&buf, name+TEMPLATE_EXT, m)
+
+	if err != nil {
+		return "", fmt.Errorf("Can't render template %q: %w", name, err)
+	}
+
+	return buf.String(), nil
+}
+
+// execTemplates executes templates with given names and returns joined result
+func execTemplates(tmpl *template.Template, names []string, m *Makefile) (string, error) {
+	var result string
+
+	for _, name := range names {
+		data, err := execTemplate(tmpl, name, m)
+
+		if err != nil {
+			return "", err
+		}
+
+		result += data
+	}
+
+	return result, nil
+}
+
+// execTemplateBlocks executes templates with given names and returns non-empty
+// results without leading and trailing empty lines. It is used for formats where
+// blocks are joined with empty line, so templates don't have to care about empty
+// lines around blocks.
+func execTemplateBlocks(tmpl *template.Template, names []string, m *Makefile) ([]string, error) {
+	var result []string
+
+	for _, name := range names {
+		data, err := execTemplate(tmpl, name, m)
+
+		if err != nil {
+			return nil, err
+		}
+
+		data = strings.Trim(data, "\n")
+
+		if data != "" {
+			result = append(result, data)
+		}
+	}
+
+	return result, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTestTargets returns packages for running tests
+func (m *Makefile) getTestTargets() string {
+	switch {
+	case !m.HasSubpackages:
+		return "."
+	case len(m.TestPaths) > 3:
+		return "./..."
+	}
+
+	return strings.Join(m.TestPaths, " ")
+}
+
+// getBinSource returns source of binary for build command
+func (m *Makefile) getBinSource(bin string) string {
+	source := m.BinSources[bin]
+
+	if getBinaryName(source) != bin {
+		return "-o " + bin + " " + source
+	}
+
+	return source
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getActionText generates colored description of action for "echo" command
+func getActionText(cur, total int, text string) string {
+	if total <= 1 {
+		return fmtc.Sprintf("{c*}%s{!}", text)
+	}
+
+	var buf bytes.Buffer
+
+	buf.WriteString(fmtc.Sprintf("{g}%s{!}", strings.Repeat("•", cur)))
+
+	if cur != total {
+		buf.WriteString(fmtc.Sprintf("{s-}%s{!}", strings.Repeat("•", total-cur)))
+	}
+
+	buf.WriteString(fmtc.Sprintf(" {c*}%s{!}", text))
+
+	return buf.String()
+}
+
+// getSortedKeys returns sorted keys of given map
+func getSortedKeys(m map[string][]string) []string {
+	return slices.Sorted(maps.Keys(m))
+}
+
+// getFuzzCount returns total number of fuzz tests
+func getFuzzCount(tests map[string][]string) int {
+	var result int
+
+	for _, funcs := range tests {
+		result += len(funcs)
+	}
+
+	return result
+}
+
+// getQuotedArgs returns given values quoted for using as shell arguments
+// in Makefile recipes
+func getQuotedArgs(values []string) string {
+	var result []string
+
+	for _, value := range values {
+		value = strings.ReplaceAll(value, "'", `'\''`)
+		value = strings.ReplaceAll(value, "$", "$$")
+		result = append(result, "'"+value+"'")
+	}
+
+	return strings.Join(result, " ")
+}
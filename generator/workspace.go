@@ -0,0 +1,250 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bufio"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/essentialkaos/ek/v13/fsutil"
+	"github.com/essentialkaos/ek/v13/path"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Module contains info about nested module
+type Module struct {
+	Dir      string // Path to module directory relative to root directory
+	Path     string // Module path from go.mod
+	Makefile *Makefile
+}
+
+// modulesTarget contains info about target which runs command in all modules
+type modulesTarget struct {
+	Name   string
+	Target string
+	Desc   string
+	Action string
+	Filter func(m *Makefile) bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Targets which run commands in all modules
+var modulesTargets = []modulesTarget{
+	{
+		"build-all", "all", "Build binaries in all modules", "Building",
+		func(m *Makefile) bool { return len(m.Binaries) != 0 },
+	},
+	{
+		"deps-all", "deps", "Download dependencies for all modules", "Downloading dependencies for",
+		func(m *Makefile) bool { return len(m.BaseImports) != 0 || m.ModUsed },
+	},
+	{
+		"test-all", "test", "Run tests in all modules", "Testing",
+		func(m *Makefile) bool { return m.HasTests },
+	},
+	{
+		"tidy-all", "tidy", "Cleanup dependencies in all modules", "Tidying up dependencies for",
+		func(m *Makefile) bool { return m.ModUsed },
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// findModules returns paths of nested modules relative to given directory. If
+// directory contains go.work file modules from "use" directives are returned,
+// otherwise all directories with go.mod file are used.
+func findModules(dir string, exclude []string) []string {
+	var modules []string
+
+	if fsutil.IsExist(dir + "/go.work") {
+		modules = getWorkspaceModules(dir + "/go.work")
+	} else {
+		files := fsutil.ListAllFiles(
+			dir, true,
+			fsutil.ListingFilter{MatchPatterns: []string{"go.mod"}},
+		)
+
+		for _, file := range files {
+			modules = append(modules, path.Dir(file))
+		}
+	}
+
+	var result []string
+
+	for _, module := range modules {
+		if module == "." || isIgnoredModuleDir(module) ||
+			isExcludedSource(module+"/go.mod", exclude) ||
+			!fsutil.IsExist(dir+"/"+module+"/go.mod") {
+			continue
+		}
+
+		if !slices.Contains(result, module) {
+			result = append(result, module)
+		}
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// getWorkspaceModules extracts paths of modules from "use" directives
+// in go.work file
+func getWorkspaceModules(file string) []string {
+	fd, err := os.OpenFile(file, os.O_RDONLY, 0)
+
+	if err != nil {
+		return nil
+	}
+
+	defer fd.Close()
+
+	var result []string
+	var isUseBlock bool
+
+	s := bufio.NewScanner(fd)
+
+	for s.Scan() {
+		text, _, _ := strings.Cut(s.Text(), "//")
+		text = strings.TrimSpace(text)
+
+		switch {
+		case text == "":
+			continue
+		case isUseBlock && text == ")":
+			isUseBlock = false
+			continue
+		case isUseBlock:
+			// Module path is used as is
+		case text == "use (" || text == "use(":
+			isUseBlock = true
+			continue
+		case strings.HasPrefix(text, "use ") || strings.HasPrefix(text, "use\t"):
+			text = strings.TrimSpace(text[4:])
+		default:
+			continue
+		}
+
+		text = path.Clean(strings.Trim(text, "\"`"))
+
+		if !path.IsAbs(text) && !strings.HasPrefix(text, "..") {
+			result = append(result, text)
+		}
+	}
+
+	return result
+}
+
+// isIgnoredModuleDir returns true if module placed in directory ignored by go tool
+func isIgnoredModuleDir(dir string) bool {
+	for _, part := range strings.Split(dir, "/") {
+		if part == "vendor" || part == "testdata" ||
+			strings.HasPrefix(part, "_") || strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitModuleSources groups sources by modules. Sources of root module are
+// stored with "." key. Paths of sources are relative to module directory.
+func splitModuleSources(sources, modules []string) map[string][]string {
+	result := make(map[string][]string)
+
+	for _, source := range sources {
+		module := getSourceModule(source, modules)
+
+		if module == "." {
+			result[module] = append(result[module], source)
+		} else {
+			result[module] = append(result[module], strings.TrimPrefix(source, module+"/"))
+		}
+	}
+
+	return result
+}
+
+// getSourceModule returns directory of the deepest module which contains
+// given source
+func getSourceModule(source string, modules []string) string {
+	result := "."
+
+	for _, module := range modules {
+		if strings.HasPrefix(source, module+"/") && len(module) > len(result) {
+			result = module
+		}
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getModulesTargetsInfo returns info about targets which delegate commands
+// to makefiles of all modules
+func (m *Makefile) getModulesTargetsInfo() []modulesTargetInfo {
+	var result []modulesTargetInfo
+
+	for _, target := range modulesTargets {
+		modules := m.getTargetModules(target)
+
+		if len(modules) == 0 {
+			continue
+		}
+
+		result = append(result, modulesTargetInfo{
+			Name:    target.Name,
+			Target:  target.Target,
+			Desc:    target.Desc,
+			Action:  target.Action,
+			Modules: modules,
+		})
+	}
+
+	return result
+}
+
+// getModulesPhony returns names of targets which delegate commands to makefiles
+// of all modules
+func (m *Makefile) getModulesPhony() []string {
+	var result []string
+
+	for _, target := range modulesTargets {
+		if len(m.getTargetModules(target)) != 0 {
+			result = append(result, target.Name)
+		}
+	}
+
+	return result
+}
+
+// getTargetModules returns modules which have makefile with given target
+func (m *Makefile) getTargetModules(target modulesTarget) []*Module {
+	if len(m.Modules) == 0 {
+		return nil
+	}
+
+	var result []*Module
+
+	if !m.IsWorkspaceRoot && target.Filter(m) {
+		result = append(result, &Module{Dir: ".", Path: m.PkgBase, Makefile: m})
+	}
+
+	for _, module := range m.Modules {
+		if target.Filter(module.Makefile) {
+			result = append(result, module)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package generator
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/essentialkaos/gomakegen/v3/internal/testutil"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+func TestFindWorkspaceModules(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.work": "go 1.22\n\n// Workspace modules\nuse (\n" +
+			"\t. // root module\n" +
+			"\t./api\n" +
+			"\t\"./tools\" // quoted path\n" +
+			"\t// ./disabled\n" +
+			"\t./missing\n" +
+			"\t../outside\n" +
+			")\n\nuse ./svc\n",
+		"go.mod":          testGoMod,
+		"api/go.mod":      testGoMod,
+		"tools/go.mod":    testGoMod,
+		"svc/go.mod":      testGoMod,
+		"disabled/go.mod": testGoMod,
+	})
+
+	modules := findModules(dir, nil)
+
+	if !slices.Equal(modules, []string{"api", "svc", "tools"}) {
+		t.Errorf("Wrong modules found in workspace: %v", modules)
+	}
+
+	modules = findModules(dir, []string{"tools"})
+
+	if !slices.Equal(modules, []string{"api", "svc"}) {
+		t.Errorf("Wrong modules found in workspace with excluded module: %v", modules)
+	}
+}
+
+func TestFindNestedModules(t *testing.T) {
+	dir := testutil.CreateProject(t, map[string]string{
+		"go.mod":              testGoMod,
+		"lib/go.mod":          testGoMod,
+		"lib/nested/go.mod":   testGoMod,
+		"vendor/dep/go.mod":   testGoMod,
+		"testdata/mod/go.mod": testGoMod,
+		"_old/go.mod":         testGoMod,
+		".cache/mod/go.mod":   testGoMod,
+		"examples/go.mod":     testGoMod,
+	})
+
+	modules := findModules(dir, []string{"examples"})
+
+	if !slices.Equal(modules, []string{"lib", "lib/nested"}) {
+		t.Errorf("Wrong nested modules found: %v", modules)
+	}
+}
+
+func TestSplitModuleSources(t *testing.T) {
+	sources := []string{
+		"main.go", "cmd/app/main.go", "lib/lib.go", "lib/nested/nested.go",
+		"lib/nested/sub/sub.go", "library/library.go",
+	}
+
+	result := splitModuleSources(sources, []string{"lib", "lib/nested"})
+
+	expected := map[string][]string{
+		".":          {"main.go", "cmd/app/main.go", "library/library.go"},
+		"lib":        {"lib.go"},
+		"lib/nested": {"nested.go", "sub/sub.go"},
+	}
+
+	if !maps.EqualFunc(result, expected, slices.Equal) {
+		t.Errorf("Wrong sources split: %v", result)
+	}
+}
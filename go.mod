@@ -2,7 +2,10 @@ module github.com/essentialkaos/gomakegen/v3
 
 go 1.23.6
 
-require github.com/essentialkaos/ek/v13 v13.27.1
+require (
+	github.com/essentialkaos/ek/v13 v13.27.1
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/essentialkaos/depsy v1.3.1 // indirect
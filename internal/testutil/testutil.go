@@ -0,0 +1,40 @@
+// Package testutil contains helpers for tests
+package testutil
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// CreateProject creates project with given files in temporary directory
+func CreateProject(t testing.TB, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, data := range files {
+		file := filepath.Join(dir, name)
+
+		err := os.MkdirAll(filepath.Dir(file), 0755)
+
+		if err == nil {
+			err = os.WriteFile(file, []byte(data), 0644)
+		}
+
+		if err != nil {
+			t.Fatalf("Can't create file %s: %v", name, err)
+		}
+	}
+
+	return dir
+}